@@ -2,6 +2,10 @@ package as
 
 import (
 	"errors"
+	"log/slog"
+	"math"
+	"math/big"
+	"net/netip"
 	"net/url"
 	"strconv"
 	"testing"
@@ -155,3 +159,654 @@ func TestAbsoluteURL_WhenNotAnAbsoluteURL(t *testing.T) {
 	test.Error(t, err).Is(ErrNotAnAbsoluteURL)
 	test.That(t, result).IsNil()
 }
+
+func TestText(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		exec     func(t *testing.T)
+	}{
+		{scenario: "netip.Addr",
+			exec: func(t *testing.T) {
+				// ACT
+				result, err := Text[netip.Addr]("192.168.0.1")
+
+				// ASSERT
+				test.That(t, err).IsNil()
+				test.That(t, result).Equals(netip.MustParseAddr("192.168.0.1"))
+			},
+		},
+		{scenario: "slog.Level",
+			exec: func(t *testing.T) {
+				// ACT
+				result, err := Text[slog.Level]("warn")
+
+				// ASSERT
+				test.That(t, err).IsNil()
+				test.That(t, result).Equals(slog.LevelWarn)
+			},
+		},
+		{scenario: "big.Int",
+			exec: func(t *testing.T) {
+				// ACT
+				result, err := Text[big.Int]("123456789012345678901234567890")
+
+				// ASSERT
+				test.That(t, err).IsNil()
+				test.That(t, result.String()).Equals("123456789012345678901234567890")
+			},
+		},
+		{scenario: "with env.Parse",
+			exec: func(t *testing.T) {
+				// ARRANGE
+				t.Setenv("ADDR", "::1")
+
+				// ACT
+				result, err := env.Parse("ADDR", Text[netip.Addr])
+
+				// ASSERT
+				test.That(t, err).IsNil()
+				test.That(t, result).Equals(netip.IPv6Loopback())
+			},
+		},
+		{scenario: "when conversion fails",
+			exec: func(t *testing.T) {
+				// ACT
+				result, err := Text[netip.Addr]("not-an-address")
+
+				// ASSERT
+				test.IsTrue(t, err != nil)
+				test.That(t, result).Equals(netip.Addr{})
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			tc.exec(t)
+		})
+	}
+}
+
+func TestBool(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		input  string
+		result bool
+		err    error
+	}{
+		{input: "true", result: true},
+		{input: "TRUE", result: true},
+		{input: "Yes", result: true},
+		{input: "on", result: true},
+		{input: "1", result: true},
+		{input: "enabled", result: true},
+		{input: "false"},
+		{input: "No"},
+		{input: "OFF"},
+		{input: "0"},
+		{input: "disabled"},
+		{input: "maybe", err: env.InvalidValueError{Value: "maybe", Err: ErrNotABool}},
+		{input: "", err: ErrNotABool},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			// ACT
+			result, err := Bool(tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.Bool(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestBoolFrom(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario        string
+		caseInsensitive bool
+		input           string
+		result          bool
+		err             error
+	}{
+		{scenario: "case-sensitive/truthy", input: "aye", result: true},
+		{scenario: "case-sensitive/falsy", input: "nay"},
+		{scenario: "case-sensitive/wrong case", input: "AYE", err: ErrNotABool},
+		{scenario: "case-insensitive/truthy", caseInsensitive: true, input: "AYE", result: true},
+		{scenario: "case-insensitive/falsy", caseInsensitive: true, input: "Nay"},
+		{scenario: "not accepted", caseInsensitive: true, input: "true", err: ErrNotABool},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			cnv := BoolFrom([]string{"aye"}, []string{"nay"}, tc.caseInsensitive)
+
+			// ACT
+			result, err := cnv(tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.Bool(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestBool_WithParse(t *testing.T) {
+	// ARRANGE
+	defer env.State().Reset()
+	env.Clear()
+	env.Set("ENABLED", "maybe")
+
+	// ACT
+	_, err := env.Parse("ENABLED", Bool)
+
+	// ASSERT
+	test.Error(t, err).Is(env.ParseError{VariableName: "ENABLED", Err: env.InvalidValueError{Value: "maybe", Err: ErrNotABool}})
+	test.That(t, err.Error()).Equals("env.ParseError: ENABLED: env.InvalidValueError: maybe: " +
+		"not a boolean: accepted values are: true, t, yes, y, on, 1, enabled, enable (true), " +
+		"false, f, no, n, off, 0, disabled, disable (false)")
+}
+
+func TestInteger(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		input    string
+		result   int64
+		err      error
+	}{
+		{scenario: "decimal", input: "42", result: 42},
+		{scenario: "negative", input: "-42", result: -42},
+		{scenario: "separators", input: "1_000_000", result: 1000000},
+		{scenario: "hexadecimal", input: "0x1F", result: 31},
+		{scenario: "octal", input: "0o17", result: 15},
+		{scenario: "binary", input: "0b1010", result: 10},
+		{scenario: "leading zero", input: "010", result: 10},
+		{scenario: "leading zero/negative", input: "-010", result: -10},
+		{scenario: "leading zero/separator", input: "0_10", result: 10},
+		{scenario: "leading zero/separator/negative", input: "-0_10", result: -10},
+		{scenario: "leading zeros", input: "00_1_0", result: 10},
+		{scenario: "leading zeros/zero", input: "00", result: 0},
+		{scenario: "leading zero/invalid separator", input: "0__10", err: strconv.ErrSyntax},
+		{scenario: "leading zero/trailing separator", input: "0_", err: strconv.ErrSyntax},
+		{scenario: "leading zeros/prefix", input: "00x1F", err: strconv.ErrSyntax},
+		{scenario: "invalid", input: "forty-two", err: strconv.ErrSyntax},
+		{scenario: "invalid separator", input: "1__000", err: strconv.ErrSyntax},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := Integer[int64](tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestInteger_WhenOutOfRange(t *testing.T) {
+	// ACT
+	_, i8err := Integer[int8]("128")
+	_, u8err := Integer[uint8]("0x100")
+	_, u64err := Integer[uint64]("-1")
+	u64, _ := Integer[uint64]("0xFFFF_FFFF_FFFF_FFFF")
+
+	// ASSERT
+	test.Error(t, i8err).Is(env.RangeError[int8]{Min: -128, Max: 127})
+	test.Error(t, u8err).Is(env.RangeError[uint8]{Min: 0, Max: 255})
+	test.Error(t, u64err).Is(strconv.ErrSyntax)
+	test.That(t, u64).Equals(uint64(math.MaxUint64))
+}
+
+func TestFloat(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		input    string
+		result   float64
+		err      error
+	}{
+		{scenario: "decimal", input: "0.25", result: 0.25},
+		{scenario: "exponent", input: "1e3", result: 1000},
+		{scenario: "invalid", input: "a quarter", err: strconv.ErrSyntax},
+		{scenario: "out of range", input: "1e309", err: env.RangeError[float64]{Min: -math.MaxFloat64, Max: math.MaxFloat64}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := Float[float64](tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestFloat_Float32(t *testing.T) {
+	// ACT
+	result, err1 := Float[float32]("0.5")
+	_, err2 := Float[float32]("1e39")
+
+	// ASSERT
+	test.That(t, err1).IsNil()
+	test.That(t, result).Equals(float32(0.5))
+	test.Error(t, err2).Is(env.RangeError[float32]{Min: -math.MaxFloat32, Max: math.MaxFloat32})
+}
+
+func TestInRange(t *testing.T) {
+	// ARRANGE
+	workers := InRange(Integer[int], 1, 256)
+	ratio := InRange(Float[float64], 0.0, 1.0)
+	testcases := []struct {
+		scenario string
+		act      func() (any, error)
+		result   any
+		err      error
+	}{
+		{scenario: "int/min", act: func() (any, error) { return workers("1") }, result: 1},
+		{scenario: "int/max", act: func() (any, error) { return workers("256") }, result: 256},
+		{scenario: "int/below min", act: func() (any, error) { return workers("0") }, result: 0, err: env.RangeError[int]{Min: 1, Max: 256}},
+		{scenario: "int/above max", act: func() (any, error) { return workers("257") }, result: 0, err: env.RangeError[int]{Min: 1, Max: 256}},
+		{scenario: "int/invalid", act: func() (any, error) { return workers("many") }, result: 0, err: strconv.ErrSyntax},
+		{scenario: "float/in range", act: func() (any, error) { return ratio("0.5") }, result: 0.5},
+		{scenario: "float/above max", act: func() (any, error) { return ratio("1.5") }, result: 0.0, err: env.RangeError[float64]{Min: 0, Max: 1}},
+		{scenario: "float/NaN", act: func() (any, error) { return ratio("NaN") }, result: 0.0, err: env.RangeError[float64]{Min: 0, Max: 1}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := tc.act()
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestSlice(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		input    string
+		opts     []SliceOption
+		result   []string
+		err      error
+	}{
+		{scenario: "empty", input: "", result: []string{}},
+		{scenario: "single", input: "a", result: []string{"a"}},
+		{scenario: "multiple", input: "a,b,c", result: []string{"a", "b", "c"}},
+		{scenario: "whitespace retained", input: "a, b ", result: []string{"a", " b "}},
+		{scenario: "empty elements retained", input: "a,,b", result: []string{"a", "", "b"}},
+		{scenario: "separator", input: "a;b,c", opts: []SliceOption{Separator(";")}, result: []string{"a", "b,c"}},
+		{scenario: "multi-char separator", input: "a::b", opts: []SliceOption{Separator("::")}, result: []string{"a", "b"}},
+		{scenario: "trim space", input: " a , b ", opts: []SliceOption{TrimSpace()}, result: []string{"a", "b"}},
+		{scenario: "drop empty", input: "a,,b,", opts: []SliceOption{DropEmpty()}, result: []string{"a", "b"}},
+		{scenario: "trim space and drop empty", input: "a, ,b", opts: []SliceOption{TrimSpace(), DropEmpty()}, result: []string{"a", "b"}},
+		{scenario: "quoted", input: `"a,b",c`, result: []string{"a,b", "c"}},
+		{scenario: "quoted/whitespace", input: `" a ", b`, opts: []SliceOption{TrimSpace()}, result: []string{" a ", "b"}},
+		{scenario: "quoted/escapes", input: `"say \"hi\"","back\\slash"`, result: []string{`say "hi"`, `back\slash`}},
+		{scenario: "quoted/empty not dropped", input: `a,"",b`, opts: []SliceOption{DropEmpty()}, result: []string{"a", "", "b"}},
+		{scenario: "quoted/unterminated", input: `a,"b`, err: env.ErrUnterminatedQuote},
+		{scenario: "unique/duplicate after dropped empty", input: ",a,a", opts: []SliceOption{Unique(), DropEmpty()}, err: ElementError{Index: 2, Err: ErrDuplicateValue}},
+		{scenario: "quote within element", input: `a"b,c`, result: []string{`a"b`, "c"}},
+		{scenario: "quote after whitespace", input: ` "a,b" ,c`, result: []string{"a,b", "c"}},
+		{scenario: "quoted/trailing characters", input: `a,"b"c`, err: ElementError{Index: 1, Err: env.ErrTrailingCharacters}},
+		{scenario: "min length", input: "a", opts: []SliceOption{MinLen(2)}, err: env.RangeError[int]{Min: 2, Max: math.MaxInt}},
+		{scenario: "min length/empty", input: "", opts: []SliceOption{MinLen(1)}, err: env.RangeError[int]{Min: 1, Max: math.MaxInt}},
+		{scenario: "max length", input: "a,b,c", opts: []SliceOption{MaxLen(2)}, err: env.RangeError[int]{Min: 0, Max: 2}},
+		{scenario: "unique", input: "a,b", opts: []SliceOption{Unique()}, result: []string{"a", "b"}},
+		{scenario: "unique/duplicate", input: "a, b,a", opts: []SliceOption{Unique(), TrimSpace()}, err: ElementError{Index: 2, Err: ErrDuplicateValue}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := Slice(String, tc.opts...)(tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			if tc.err != nil {
+				test.Error(t, err).Is(env.InvalidValueError{Value: tc.input})
+			}
+			test.Slice(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestSlice_WhenElementConversionFails(t *testing.T) {
+	// ARRANGE
+	defer env.State().Reset()
+	env.Clear()
+	env.Set("PORTS", "80,http,443")
+
+	// ACT
+	result, err := env.Parse("PORTS", Slice(PortNo))
+
+	// ASSERT
+	test.That(t, result == nil).Equals(true)
+	test.Error(t, err).Is(ElementError{Index: 1, Err: strconv.ErrSyntax})
+	test.That(t, err.Error()).Equals(`env.ParseError: PORTS: env.InvalidValueError: 80,http,443: ` +
+		`as.ElementError: index 1: strconv.Atoi: parsing "http": invalid syntax`)
+}
+
+func TestSlice_WhenElementConversionFailsAfterDroppedElements(t *testing.T) {
+	// ACT
+	result, err := Slice(Int, DropEmpty())(",,x")
+
+	// ASSERT
+	test.That(t, result == nil).Equals(true)
+	test.Error(t, err).Is(ElementError{Index: 2, Err: strconv.ErrSyntax})
+}
+
+func TestParserReport_IsRedacted(t *testing.T) {
+	// ARRANGE
+	defer env.State().Reset()
+	env.Clear()
+	env.Set("AUTH_SERVICE_URL", "ht tp://supersecret:pw@host")
+	env.Set("FLAGS", "supersecretvalue,true")
+	p := env.NewParser()
+
+	// ACT
+	env.ParseWith(p, "AUTH_SERVICE_URL", AbsoluteURL)
+	env.ParseWith(p, "FLAGS", Slice(Bool))
+	report := p.Report()
+
+	// ASSERT
+	test.String(t, report).Contains("first path segment in URL cannot contain colon")
+	test.String(t, report).Contains("index 0: not a boolean: accepted values are:")
+	test.String(t, report).DoesNotContain("supersecret")
+}
+
+func TestMap(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		input    string
+		result   map[string]int
+		err      error
+	}{
+		{scenario: "empty", input: "", result: map[string]int{}},
+		{scenario: "single", input: "a=1", result: map[string]int{"a": 1}},
+		{scenario: "multiple", input: "a=1, b = 2", result: map[string]int{"a": 1, "b": 2}},
+		{scenario: "empty pairs", input: "a=1,,b=2,", result: map[string]int{"a": 1, "b": 2}},
+		{scenario: "value with separator", input: "a=1=2", err: ElementError{Index: 0, Key: "a", Err: strconv.ErrSyntax}},
+		{scenario: "quoted key", input: `"a,=b"=1`, result: map[string]int{"a,=b": 1}},
+		{scenario: "empty key", input: `=1`, result: map[string]int{"": 1}},
+		{scenario: "missing separator", input: "a=1,b", err: ElementError{Index: 1, Err: ErrMissingSeparator}},
+		{scenario: "duplicate key", input: "a=1,a=2", err: ElementError{Index: 1, Key: "a", Err: ErrDuplicateKey}},
+		{scenario: "invalid value", input: "a=1,b=two", err: ElementError{Index: 1, Key: "b", Err: strconv.ErrSyntax}},
+		{scenario: "unterminated quote", input: `a="1`, err: env.ErrUnterminatedQuote},
+		{scenario: "quote within value", input: `a=1"2,b=3`, err: ElementError{Index: 0, Key: "a", Err: strconv.ErrSyntax}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := Map(String, Int, ",", "=")(tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			if tc.err != nil {
+				test.Error(t, err).Is(env.InvalidValueError{Value: tc.input})
+			}
+			test.Map(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestMap_QuotedValues(t *testing.T) {
+	// ACT
+	result, err := Map(String, String, ";", ":")(`greeting:"hello; world" ; empty:""`)
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.Map(t, result).Equals(map[string]string{"greeting": "hello; world", "empty": ""})
+}
+
+func TestMap_WhenKeyConversionFails(t *testing.T) {
+	// ACT
+	_, err := Map(Int, String, ",", "=")("1=a,two=b")
+
+	// ASSERT
+	test.Error(t, err).Is(ElementError{Index: 1, Key: "two", Err: strconv.ErrSyntax})
+	test.Error(t, err).Is(ElementError{Index: -1})
+}
+
+func TestOneOf(t *testing.T) {
+	// ARRANGE
+	cnv := OneOf("json", "text", "logfmt")
+	testcases := []struct {
+		input  string
+		result string
+		err    error
+	}{
+		{input: "json", result: "json"},
+		{input: "logfmt", result: "logfmt"},
+		{input: "JSON", err: env.InvalidValueError{Value: "JSON", Err: ErrNotPermitted}},
+		{input: "xml", err: ErrNotPermitted},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			// ACT
+			result, err := cnv(tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestOneOfIgnoreCase(t *testing.T) {
+	// ARRANGE
+	cnv := OneOfIgnoreCase("json", "text")
+
+	// ACT
+	result, err := cnv("JSON")
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, result).Equals("json")
+}
+
+func TestEnum(t *testing.T) {
+	// ARRANGE
+	type mode int
+	values := map[string]mode{"primary": 1, "replica": 2}
+	testcases := []struct {
+		scenario string
+		input    string
+		opts     []EnumOption
+		result   mode
+		err      error
+	}{
+		{scenario: "match", input: "replica", result: 2},
+		{scenario: "case-sensitive", input: "Replica", err: ErrNotPermitted},
+		{scenario: "ignore case", input: "Replica", opts: []EnumOption{IgnoreCase()}, result: 2},
+		{scenario: "no match", input: "standby", err: env.InvalidValueError{Value: "standby", Err: ErrNotPermitted}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := Enum(values, tc.opts...)(tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestEnum_ErrorMessage(t *testing.T) {
+	// ARRANGE
+	cnv := Enum(map[string]int{"primary": 1, "replica": 2})
+	testcases := []struct {
+		input  string
+		errmsg string
+	}{
+		{input: "replca", errmsg: `env.InvalidValueError: replca: not a permitted value: permitted values are: primary, replica; did you mean "replica"?`},
+		{input: "PRIMARY", errmsg: `env.InvalidValueError: PRIMARY: not a permitted value: permitted values are: primary, replica; did you mean "primary"?`},
+		{input: "standby", errmsg: `env.InvalidValueError: standby: not a permitted value: permitted values are: primary, replica`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			// ACT
+			_, err := cnv(tc.input)
+
+			// ASSERT
+			test.That(t, err.Error()).Equals(tc.errmsg)
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	testcases := []struct {
+		a, b   string
+		result int
+	}{
+		{a: "", b: "", result: 0},
+		{a: "abc", b: "", result: 3},
+		{a: "", b: "abc", result: 3},
+		{a: "replica", b: "replica", result: 0},
+		{a: "replca", b: "replica", result: 1},
+		{a: "kitten", b: "sitting", result: 3},
+		{a: "naïve", b: "naive", result: 1},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			// ACT
+			result := editDistance(tc.a, tc.b)
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestByteSize(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		input  string
+		unit   []Bytes
+		result Bytes
+		err    error
+	}{
+		{input: "4096", result: 4096},
+		{input: "100B", result: 100},
+		{input: "512MiB", result: 512 * MiB},
+		{input: "512 mib", result: 512 * MiB},
+		{input: "10MB", result: 10 * MB},
+		{input: "1.5G", result: 1500 * MB},
+		{input: "1.5Gi", result: 1536 * MiB},
+		{input: "1.5KiB", result: 1536},
+		{input: "0.5B", result: 0},
+		{input: "+2k", result: 2 * KB},
+		{input: "8EiB", err: env.RangeError[int64]{Min: 0, Max: math.MaxInt64}},
+		{input: "7.5EiB", result: 7*EiB + EiB/2},
+		{input: "-1", err: env.RangeError[int64]{Min: 0, Max: math.MaxInt64}},
+		{input: "-0.5B", err: env.RangeError[int64]{Min: 0, Max: math.MaxInt64}},
+		{input: "-0", result: 0},
+		{input: "512", unit: []Bytes{MiB}, result: 512 * MiB},
+		{input: "512KB", unit: []Bytes{MiB}, result: 512 * KB},
+		{input: "MiB", err: ErrInvalidByteSize},
+		{input: "1.2.3MB", err: ErrInvalidByteSize},
+		{input: "12XB", err: ErrInvalidByteSize},
+		{input: "", err: ErrInvalidByteSize},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			// ACT
+			result, err := ByteSize(tc.input, tc.unit...)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			if tc.err == nil {
+				test.That(t, result).Equals(tc.result)
+			}
+		})
+	}
+}
+
+func TestByteSize_WhenOutOfRange(t *testing.T) {
+	// ACT
+	_, err := ByteSize("8EiB")
+
+	// ASSERT
+	test.That(t, err.Error()).Equals("env.RangeError: 0 <= (x) <= 9223372036854775807")
+}
+
+func TestBytes_String(t *testing.T) {
+	testcases := []struct {
+		bytes  Bytes
+		result string
+	}{
+		{bytes: 0, result: "0B"},
+		{bytes: 100, result: "100B"},
+		{bytes: 1000, result: "1KB"},
+		{bytes: 1001, result: "1.001KB"},
+		{bytes: 1024, result: "1KiB"},
+		{bytes: 512 * MiB, result: "512MiB"},
+		{bytes: 10 * MB, result: "10MB"},
+		{bytes: 1500 * MB, result: "1.5GB"},
+		{bytes: 1024000000, result: "1.024GB"},
+		{bytes: 3072000, result: "3.072MB"},
+		{bytes: 1536, result: "1.5KiB"},
+		{bytes: 1537, result: "1.537KB"},
+		{bytes: 1234567, result: "1.18MiB"},
+		{bytes: 1000 * KiB, result: "1.024MB"},
+		{bytes: 7 * EiB, result: "7EiB"},
+		{bytes: -2 * GiB, result: "-2GiB"},
+		{bytes: math.MinInt64, result: "-8EiB"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.result, func(t *testing.T) {
+			// ACT
+			result := tc.bytes.String()
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestBytes_WithParse(t *testing.T) {
+	// ARRANGE
+	defer env.State().Reset()
+	env.Clear()
+	env.Set("CACHE_SIZE", "256MiB")
+
+	// ACT
+	result, err := env.Parse("CACHE_SIZE", Text[Bytes])
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, result).Equals(256 * MiB)
+}
+
+func TestSlice_WithEmptySeparator(t *testing.T) {
+	// ARRANGE
+	defer test.ExpectPanic(ErrEmptySeparator).Assert(t)
+
+	// ACT
+	Slice(String, Separator(""))
+}
+
+func TestMap_WithEmptySeparator(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		pairSep  string
+		kvSep    string
+	}{
+		{scenario: "pair separator", pairSep: "", kvSep: "="},
+		{scenario: "key/value separator", pairSep: ",", kvSep: ""},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ARRANGE
+			defer test.ExpectPanic(ErrEmptySeparator).Assert(t)
+
+			// ACT
+			Map(String, String, tc.pairSep, tc.kvSep)
+		})
+	}
+}
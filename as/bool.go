@@ -0,0 +1,67 @@
+package as
+
+import (
+	"slices"
+
+	"github.com/blugnu/env"
+	"github.com/blugnu/env/internal/conv"
+)
+
+// Bool converts a string to a bool.  In addition to the values accepted by
+// strconv.ParseBool, Bool accepts the words commonly used in configuration
+// (case-insensitive):
+//
+//	true:  true, t, yes, y, on, 1, enabled, enable
+//	false: false, f, no, n, off, 0, disabled, disable
+//
+// # parameters
+//
+//	s string   // the string to convert
+//
+// # returns
+//
+//	bool    // the converted value
+//
+//	error   // an env.InvalidValueError wrapping ErrNotABool if the string is
+//	        // not an accepted value
+func Bool(s string) (bool, error) {
+	return boolFrom(conv.Truthy, conv.Falsy, true)(s)
+}
+
+// BoolFrom returns a function that converts a string to a bool using specified
+// vocabularies of words representing true and false.
+//
+// # parameters
+//
+//	truthy []string        // words representing true
+//
+//	falsy []string         // words representing false
+//
+//	caseInsensitive bool   // true if words are matched regardless of case
+//
+// # returns
+//
+//	env.ConversionFunc[bool]   // a function converting a string to a bool; the
+//	                           // function returns an env.InvalidValueError wrapping
+//	                           // ErrNotABool, identifying the accepted values, if the
+//	                           // string is not an accepted value
+//
+// # example
+//
+//	enabled, err := env.Parse("FEATURE_X", as.BoolFrom([]string{"on"}, []string{"off"}, false))
+func BoolFrom(truthy, falsy []string, caseInsensitive bool) env.ConversionFunc[bool] {
+	return boolFrom(slices.Clone(truthy), slices.Clone(falsy), caseInsensitive)
+}
+
+// boolFrom returns a function converting a string to a bool using the specified
+// vocabularies.
+func boolFrom(truthy, falsy []string, caseInsensitive bool) env.ConversionFunc[bool] {
+	cnv := conv.BoolFrom(truthy, falsy, caseInsensitive)
+	return func(s string) (bool, error) {
+		b, err := cnv(s)
+		if err != nil {
+			return false, env.InvalidValueError{Value: s, Err: err}
+		}
+		return b, nil
+	}
+}
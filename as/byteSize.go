@@ -0,0 +1,222 @@
+package as
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/blugnu/env"
+)
+
+// Bytes is a number of bytes, as returned by ByteSize.
+type Bytes int64
+
+// Units of Bytes.  SI units are powers of 1000; IEC units are powers of 1024.
+const (
+	Byte Bytes = 1
+
+	KB Bytes = 1000 * Byte
+	MB Bytes = 1000 * KB
+	GB Bytes = 1000 * MB
+	TB Bytes = 1000 * GB
+	PB Bytes = 1000 * TB
+	EB Bytes = 1000 * PB
+
+	KiB Bytes = 1024 * Byte
+	MiB Bytes = 1024 * KiB
+	GiB Bytes = 1024 * MiB
+	TiB Bytes = 1024 * GiB
+	PiB Bytes = 1024 * TiB
+	EiB Bytes = 1024 * PiB
+)
+
+// byteUnits are the units recognised by ByteSize (matched regardless of case),
+// and the units used by Bytes.String, in descending order of size.
+var byteUnits = []struct {
+	name  string
+	alt   string
+	bytes Bytes
+}{
+	{"EiB", "Ei", EiB}, {"EB", "E", EB},
+	{"PiB", "Pi", PiB}, {"PB", "P", PB},
+	{"TiB", "Ti", TiB}, {"TB", "T", TB},
+	{"GiB", "Gi", GiB}, {"GB", "G", GB},
+	{"MiB", "Mi", MiB}, {"MB", "M", MB},
+	{"KiB", "Ki", KiB}, {"KB", "K", KB},
+	{"B", "", Byte},
+}
+
+// ByteSize converts a string to a number of bytes.  The string is a number,
+// optionally with a fractional part, followed by an optional unit (case-insensitive,
+// optionally separated from the number by whitespace):
+//
+//	B                              // bytes
+//	K, KB, M, MB, G, GB, ... EB    // SI units: powers of 1000
+//	Ki, KiB, Mi, MiB, ... EiB      // IEC units: powers of 1024
+//
+// For example: "512MiB", "10MB", "1.5G" or "4096".  A fractional number of bytes
+// is truncated (e.g. "1.5KiB" is 1536 bytes, "0.5B" is 0 bytes).
+//
+// # parameters
+//
+//	s string       // the string to convert
+//
+//	u ...Bytes     // the unit of a string that does not specify a unit; if no unit
+//	               // is provided the default is Byte.  If multiple units are
+//	               // provided, only the first is used
+//
+// # returns
+//
+//	Bytes   // the converted value
+//
+//	error   // any error that occurs during conversion; if the value is negative
+//	        // or too large to be represented, an env.RangeError[int64] (the
+//	        // range of Bytes, in bytes)
+//
+// # example
+//
+//	size, err := as.ByteSize("1.5G")        // 1500000000
+//	size, err := as.ByteSize("512", as.MiB) // 536870912
+//
+// To use ByteSize with env.Parse, use as.Text (Bytes implements
+// encoding.TextUnmarshaler, with no default unit):
+//
+//	size, err := env.Parse("CACHE_SIZE", as.Text[as.Bytes])
+func ByteSize(s string, u ...Bytes) (Bytes, error) {
+	unit := Byte
+	if len(u) > 0 {
+		unit = u[0]
+	}
+
+	t := strings.TrimSpace(s)
+	n := 0
+	if n < len(t) && (t[n] == '-' || t[n] == '+') {
+		n++
+	}
+	digits := 0
+	for dot := false; n < len(t) && (isDigit(t[n]) || (t[n] == '.' && !dot)); n++ {
+		if t[n] == '.' {
+			dot = true
+			continue
+		}
+		digits++
+	}
+	if digits == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidByteSize, s)
+	}
+	number, suffix := t[:n], strings.TrimSpace(t[n:])
+
+	if suffix != "" {
+		unit = 0
+		for _, bu := range byteUnits {
+			if strings.EqualFold(suffix, bu.name) || (bu.alt != "" && strings.EqualFold(suffix, bu.alt)) {
+				unit = bu.bytes
+				break
+			}
+		}
+		if unit == 0 {
+			return 0, fmt.Errorf("%w: unknown unit: %q", ErrInvalidByteSize, suffix)
+		}
+	}
+
+	r, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidByteSize, s)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(int64(unit)))
+	outOfRange := env.RangeError[int64]{Min: 0, Max: math.MaxInt64}
+	if r.Sign() < 0 {
+		return 0, outOfRange
+	}
+
+	// truncate any fractional number of bytes
+	i := new(big.Int).Quo(r.Num(), r.Denom())
+	if !i.IsInt64() {
+		return 0, outOfRange
+	}
+	return Bytes(i.Int64()), nil
+}
+
+// isDigit returns true if c is a decimal digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// String returns a human-readable representation of the number of bytes, using
+// the largest unit (SI or IEC) in which the number is exact and less than 1000:
+//
+//	Bytes(536870912).String()    // 512MiB
+//	Bytes(10000000).String()     // 10MB
+//	Bytes(100).String()          // 100B
+//
+// Otherwise, the largest unit in which the number is exact to three decimal places
+// and less than 1000 is used:
+//
+//	Bytes(1500000000).String()   // 1.5GB
+//	Bytes(1001).String()         // 1.001KB
+//
+// If there is no such unit, the largest unit not exceeding the number is used, with
+// up to two decimal places:
+//
+//	Bytes(1234567).String()      // 1.18MiB
+func (b Bytes) String() string {
+	switch {
+	case b == math.MinInt64:
+		return "-8EiB" // cannot be negated
+	case b < 0:
+		return "-" + (-b).String()
+	case b < KB:
+		return strconv.FormatInt(int64(b), 10) + "B"
+	}
+
+	// units other than B, in descending order of size
+	units := byteUnits[:len(byteUnits)-1]
+	for _, bu := range units {
+		if n := b / bu.bytes; n < 1000 && b%bu.bytes == 0 {
+			return strconv.FormatInt(int64(n), 10) + bu.name
+		}
+	}
+
+	// the remainder is exact to three decimal places if it is a multiple of
+	// unit/gcd(unit, 1000), avoiding any overflow of remainder*1000
+	for _, bu := range units {
+		n, r := b/bu.bytes, b%bu.bytes
+		if n == 0 || n >= 1000 {
+			continue
+		}
+		g := gcd(bu.bytes, 1000)
+		if d := bu.bytes / g; r%d == 0 {
+			f := fmt.Sprintf("%d.%03d", n, r/d*(1000/g))
+			return strings.TrimRight(f, "0") + bu.name
+		}
+	}
+
+	for _, bu := range units {
+		if b >= bu.bytes {
+			f := strconv.FormatFloat(float64(b)/float64(bu.bytes), 'f', 2, 64)
+			return strings.TrimRight(strings.TrimRight(f, "0"), ".") + bu.name
+		}
+	}
+	return strconv.FormatInt(int64(b), 10) + "B"
+}
+
+// gcd returns the greatest common divisor of two positive numbers.
+func gcd(a, b Bytes) Bytes {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// UnmarshalText sets the number of bytes by converting text using ByteSize, with
+// no default unit.  This allows Bytes to be used with as.Text and env.Bind.
+func (b *Bytes) UnmarshalText(text []byte) error {
+	v, err := ByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
@@ -0,0 +1,172 @@
+package as
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/blugnu/env"
+)
+
+// EnumOption is a function that configures the conversion of a string by Enum.
+type EnumOption func(*enumOptions)
+
+// enumOptions holds the configuration applied by EnumOptions.
+type enumOptions struct {
+	ignoreCase bool
+}
+
+// IgnoreCase returns an EnumOption that matches values regardless of case.
+func IgnoreCase() EnumOption {
+	return func(o *enumOptions) {
+		o.ignoreCase = true
+	}
+}
+
+// OneOf returns a function that accepts a string only if it is one of a number of
+// permitted values (case-sensitive).
+//
+// # parameters
+//
+//	values ...string   // the permitted values
+//
+// # returns
+//
+//	env.ConversionFunc[string]   // a function returning the string if it is a
+//	                             // permitted value; otherwise an error as for Enum
+//
+// # example
+//
+//	format, err := env.Parse("LOG_FORMAT", as.OneOf("json", "text", "logfmt"))
+func OneOf(values ...string) env.ConversionFunc[string] {
+	return enum(slices.Clone(values), func(s string) string { return s }, false)
+}
+
+// OneOfIgnoreCase returns a function that accepts a string if it is one of a number
+// of permitted values, regardless of case.  The permitted value is returned, as
+// specified (e.g. "JSON" is converted to "json" if "json" is permitted).
+//
+// # parameters
+//
+//	values ...string   // the permitted values
+//
+// # returns
+//
+//	env.ConversionFunc[string]   // a function returning the permitted value matching
+//	                             // the string; otherwise an error as for Enum
+func OneOfIgnoreCase(values ...string) env.ConversionFunc[string] {
+	return enum(slices.Clone(values), func(s string) string { return s }, true)
+}
+
+// Enum returns a function that converts a string to a value of type T using a map
+// of the permitted names and corresponding values.
+//
+// # parameters
+//
+//	values map[string]T   // the permitted names and their values
+//
+//	opts ...EnumOption    // (optional) options, e.g. IgnoreCase
+//
+// # returns
+//
+//	env.ConversionFunc[T]   // a function converting a string to the value of the
+//	                        // matching name
+//
+// # errors
+//
+// A string that does not match any permitted name results in an env.InvalidValueError
+// wrapping ErrNotPermitted, identifying the permitted names and suggesting the
+// closest permitted name if the string is similar to one:
+//
+//	not a permitted value: permitted values are: primary, replica; did you mean "replica"?
+//
+// # example
+//
+//	type Mode int
+//	const (
+//		Primary Mode = iota
+//		Replica
+//	)
+//	mode, err := env.Parse("MODE", as.Enum(map[string]Mode{
+//		"primary": Primary,
+//		"replica": Replica,
+//	}, as.IgnoreCase()))
+func Enum[T any](values map[string]T, opts ...EnumOption) env.ConversionFunc[T] {
+	o := &enumOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	names := make([]string, 0, len(values))
+	m := make(map[string]T, len(values))
+	for k, v := range values {
+		names = append(names, k)
+		m[k] = v
+	}
+	slices.Sort(names)
+
+	return enum(names, func(name string) T { return m[name] }, o.ignoreCase)
+}
+
+// enum returns a function that converts a string matching one of a number of
+// permitted names to a value, using a function returning the value of each name.
+func enum[T any](names []string, value func(string) T, ignoreCase bool) env.ConversionFunc[T] {
+	match := func(s string) func(string) bool {
+		if ignoreCase {
+			return func(name string) bool { return strings.EqualFold(s, name) }
+		}
+		return func(name string) bool { return s == name }
+	}
+
+	return func(s string) (T, error) {
+		if i := slices.IndexFunc(names, match(s)); i != -1 {
+			return value(names[i]), nil
+		}
+
+		msg := "permitted values are: " + strings.Join(names, ", ")
+		if suggestion, ok := closest(s, names); ok {
+			msg += fmt.Sprintf("; did you mean %q?", suggestion)
+		}
+		return *new(T), env.InvalidValueError{Value: s, Err: fmt.Errorf("%w: %s", ErrNotPermitted, msg)}
+	}
+}
+
+// closest returns the name closest to a string, by edit distance (ignoring case).
+// A name is returned only if the edit distance is no more than half the length of
+// the name, so that dissimilar names are not suggested.
+func closest(s string, names []string) (string, bool) {
+	result, best := "", -1
+	for _, name := range names {
+		d := editDistance(strings.ToLower(s), strings.ToLower(name))
+		if d <= len([]rune(name))/2 && (best == -1 || d < best) {
+			result, best = name, d
+		}
+	}
+	return result, best != -1
+}
+
+// editDistance returns the Levenshtein distance between two strings: the number
+// of single character insertions, deletions or substitutions required to change
+// one string into the other.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// prev and curr are the distances between a prefix of a and each prefix of b
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -1,7 +1,72 @@
 package as
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/blugnu/env/internal/conv"
+)
 
 var (
+	ErrDuplicateKey     = errors.New("duplicate key")
+	ErrDuplicateValue   = errors.New("duplicate value")
+	ErrEmptySeparator   = errors.New("empty separator")
+	ErrInvalidByteSize  = errors.New("invalid byte size")
+	ErrMissingSeparator = errors.New("missing key/value separator")
+	ErrNotABool         = conv.ErrNotABool
 	ErrNotAnAbsoluteURL = errors.New("not an absolute URI")
+	ErrNotPermitted     = errors.New("not a permitted value")
 )
+
+// ElementError is an error that identifies an element of a slice or map that could
+// not be converted.  Index is the index of the element in the slice, or of the
+// key/value pair in the map.  For a map, Key is the key of the pair (if the pair
+// has a key).
+type ElementError struct {
+	Index int
+	Key   string
+	Err   error
+}
+
+// Error returns a string representation of the error in the form:
+//
+//	as.ElementError: index <index>: <error>
+//
+// or, if Key is not empty:
+//
+//	as.ElementError: key "<key>": <error>
+func (e ElementError) Error() string {
+	return fmt.Sprintf("as.ElementError: %s: %v", e.Element(), e.Err)
+}
+
+// Element returns a description of the element identified by the error, without
+// the error, in the form "index <index>" or, if Key is not empty, "key "<key>"".
+// This allows an env.Parser to report the element without the (redacted) value.
+func (e ElementError) Element() string {
+	if e.Key != "" {
+		return "key " + strconv.Quote(e.Key)
+	}
+	return "index " + strconv.Itoa(e.Index)
+}
+
+// Is reports whether the target error is a match for the receiver.
+// To be a match, the target must:
+//
+//   - be an ElementError
+//   - the target Index and Key fields must match those of the receiver, or the
+//     target Index must be -1 (any index) and Key must be empty (any key)
+//   - the target Err field must satisfy errors.Is with respect to the receiver
+//     Err, or be nil
+func (e ElementError) Is(target error) bool {
+	if target, ok := target.(ElementError); ok {
+		return ((target.Index == -1 && target.Key == "") || (e.Index == target.Index && e.Key == target.Key)) &&
+			(target.Err == nil || errors.Is(e.Err, target.Err))
+	}
+	return false
+}
+
+// Unwrap returns the error that caused the element error.
+func (e ElementError) Unwrap() error {
+	return e.Err
+}
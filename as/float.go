@@ -0,0 +1,45 @@
+package as
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"unsafe"
+
+	"github.com/blugnu/env"
+)
+
+// Float converts a string to a floating-point number of type T, as for
+// strconv.ParseFloat.
+//
+// # parameters
+//
+//	s string   // the string to convert
+//
+// # returns
+//
+//	T       // the converted value
+//
+//	error   // any error that occurs during conversion; if the value cannot be
+//	        // represented by T, an env.RangeError[T] identifying the range of T
+//
+// # example
+//
+//	ratio, err := env.Parse("SAMPLE_RATIO", as.InRange(as.Float[float64], 0.0, 1.0))
+func Float[T ~float32 | ~float64](s string) (T, error) {
+	var zero T
+	bits := int(unsafe.Sizeof(zero)) * 8
+
+	f, err := strconv.ParseFloat(s, bits)
+	if errors.Is(err, strconv.ErrRange) && math.IsInf(f, 0) {
+		max := math.MaxFloat64
+		if bits == 32 {
+			max = math.MaxFloat32
+		}
+		return 0, env.RangeError[T]{Min: T(-max), Max: T(max)}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return T(f), nil
+}
@@ -0,0 +1,45 @@
+package as
+
+import (
+	"cmp"
+
+	"github.com/blugnu/env"
+)
+
+// InRange returns a function that converts a string using a specified conversion
+// function, returning an error if the converted value is not in a specified
+// (inclusive) range.
+//
+// # parameters
+//
+//	cnv env.ConversionFunc[T]   // the function to convert the string
+//
+//	min T                       // the minimum valid value
+//
+//	max T                       // the maximum valid value
+//
+// # returns
+//
+//	env.ConversionFunc[T]   // a function converting a string to a value of type T;
+//	                        // if the value is out of range, the function returns
+//	                        // an env.RangeError[T] identifying the range
+//
+// Any error returned by the conversion function is returned without being checked
+// against the range.
+//
+// # example
+//
+//	workers, err := env.Parse("WORKERS", as.InRange(as.Integer[int], 1, 256))
+func InRange[T cmp.Ordered](cnv env.ConversionFunc[T], min, max T) env.ConversionFunc[T] {
+	return func(s string) (T, error) {
+		v, err := cnv(s)
+		if err != nil {
+			return v, err
+		}
+		// expressed as !(in range) so that a NaN is out of range
+		if !(v >= min && v <= max) {
+			return *new(T), env.RangeError[T]{Min: min, Max: max}
+		}
+		return v, nil
+	}
+}
@@ -0,0 +1,56 @@
+package as
+
+import (
+	"errors"
+	"strconv"
+	"unsafe"
+
+	"github.com/blugnu/env"
+	"github.com/blugnu/env/internal/conv"
+)
+
+// Integer converts a string to an integer of type T.  The string may have a base
+// prefix and may include underscores between digits, as for Go integer literals:
+//
+//	1_000_000   // decimal
+//	0x1F        // hexadecimal (0X also accepted)
+//	0o17        // octal (0O also accepted)
+//	0b1010      // binary (0B also accepted)
+//
+// Unlike Go integer literals, a leading zero does not introduce an octal value; a
+// string such as "010" is converted as the decimal value 10.
+//
+// # parameters
+//
+//	s string   // the string to convert
+//
+// # returns
+//
+//	T       // the converted value
+//
+//	error   // any error that occurs during conversion; if the value cannot be
+//	        // represented by T, an env.RangeError[T] identifying the range of T
+//
+// # example
+//
+//	mask, err := env.Parse("UMASK", as.Integer[uint16])
+func Integer[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](s string) (T, error) {
+	var zero T
+	bits := int(unsafe.Sizeof(zero)) * 8
+	signed := zero-1 < 0
+
+	if signed {
+		i, err := conv.Int(s, bits)
+		if errors.Is(err, strconv.ErrRange) {
+			min, max := int64(-1)<<(bits-1), int64(1)<<(bits-1)-1
+			return 0, env.RangeError[T]{Min: T(min), Max: T(max)}
+		}
+		return T(i), err
+	}
+
+	u, err := conv.Uint(s, bits)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, env.RangeError[T]{Min: 0, Max: T(^uint64(0) >> (64 - bits))}
+	}
+	return T(u), err
+}
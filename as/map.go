@@ -0,0 +1,98 @@
+package as
+
+import (
+	"fmt"
+
+	"github.com/blugnu/env"
+)
+
+// Map returns a function that converts a string to a map, converting the key and
+// value of each key/value pair using specified conversion functions.
+//
+// Leading and trailing whitespace is removed from each key and value.  A key or
+// value may be enclosed in double quotes, allowing it to contain either separator
+// or leading or trailing whitespace.  Within quotes, a backslash escapes the
+// following character (e.g. \" or \\).  Quotes are removed before the key or value
+// is converted.
+//
+// Empty pairs are ignored; an empty string is converted to an empty map.
+//
+// # parameters
+//
+//	keyCnv env.ConversionFunc[K]   // a function to convert each key
+//
+//	valCnv env.ConversionFunc[V]   // a function to convert each value
+//
+//	pairSep string                 // the separator between key/value pairs, e.g. ","
+//
+//	kvSep string                   // the separator between the key and value in each
+//	                               // pair, e.g. "=" or ":"
+//
+// # returns
+//
+//	env.ConversionFunc[map[K]V]   // a function converting a string to a map
+//
+// Map panics with ErrEmptySeparator if either separator is empty.
+//
+// # errors
+//
+// Errors are returned as an env.InvalidValueError with the string being converted,
+// wrapping an ElementError identifying the index of the pair and its key (if any).
+// A pair with no key/value separator results in ErrMissingSeparator; a key that
+// occurs more than once results in ErrDuplicateKey.
+//
+// # example
+//
+//	limits, err := env.Parse("RATE_LIMITS", as.Map(as.String, as.Integer[int], ",", "="))
+//
+//	// RATE_LIMITS="login=5, search=100"
+func Map[K comparable, V any](keyCnv env.ConversionFunc[K], valCnv env.ConversionFunc[V], pairSep, kvSep string) env.ConversionFunc[map[K]V] {
+	if pairSep == "" || kvSep == "" {
+		panic(fmt.Errorf("as.Map: %w", ErrEmptySeparator))
+	}
+
+	return func(s string) (map[K]V, error) {
+		invalid := func(err error) (map[K]V, error) {
+			return nil, env.InvalidValueError{Value: s, Err: err}
+		}
+
+		pairs, err := split(s, pairSep, 0, kvSep)
+		if err != nil {
+			return invalid(err)
+		}
+
+		result := make(map[K]V, len(pairs))
+		for i, pair := range pairs {
+			kv, _ := split(pair, kvSep, 2, "")
+			if len(kv) == 1 {
+				if k, quoted, _ := unquote(kv[0], true); k == "" && !quoted {
+					continue
+				}
+				return invalid(ElementError{Index: i, Err: ErrMissingSeparator})
+			}
+
+			ks, _, err := unquote(kv[0], true)
+			if err != nil {
+				return invalid(ElementError{Index: i, Err: err})
+			}
+			vs, _, err := unquote(kv[1], true)
+			if err != nil {
+				return invalid(ElementError{Index: i, Key: ks, Err: err})
+			}
+
+			k, err := keyCnv(ks)
+			if err != nil {
+				return invalid(ElementError{Index: i, Key: ks, Err: err})
+			}
+			if _, exists := result[k]; exists {
+				return invalid(ElementError{Index: i, Key: ks, Err: ErrDuplicateKey})
+			}
+			v, err := valCnv(vs)
+			if err != nil {
+				return invalid(ElementError{Index: i, Key: ks, Err: err})
+			}
+			result[k] = v
+		}
+		return result, nil
+	}
+}
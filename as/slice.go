@@ -0,0 +1,170 @@
+package as
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/blugnu/env"
+)
+
+// SliceOption is a function that configures the conversion of a string to a slice
+// by Slice.
+type SliceOption func(*sliceOptions)
+
+// sliceOptions holds the configuration applied by SliceOptions.
+type sliceOptions struct {
+	separator string
+	trim      bool
+	dropEmpty bool
+	minLen    int
+	maxLen    int
+	unique    bool
+}
+
+// Separator returns a SliceOption that specifies the separator between elements.
+// The default separator is a comma (",").
+func Separator(sep string) SliceOption {
+	return func(o *sliceOptions) {
+		o.separator = sep
+	}
+}
+
+// TrimSpace returns a SliceOption that removes leading and trailing whitespace
+// from each (unquoted) element.
+func TrimSpace() SliceOption {
+	return func(o *sliceOptions) {
+		o.trim = true
+	}
+}
+
+// DropEmpty returns a SliceOption that removes empty (unquoted) elements.  If
+// combined with TrimSpace, elements consisting only of whitespace are also
+// removed.
+func DropEmpty() SliceOption {
+	return func(o *sliceOptions) {
+		o.dropEmpty = true
+	}
+}
+
+// MinLen returns a SliceOption that specifies the minimum number of elements.
+func MinLen(n int) SliceOption {
+	return func(o *sliceOptions) {
+		o.minLen = n
+	}
+}
+
+// MaxLen returns a SliceOption that specifies the maximum number of elements.
+func MaxLen(n int) SliceOption {
+	return func(o *sliceOptions) {
+		o.maxLen = n
+	}
+}
+
+// Unique returns a SliceOption that rejects a slice with duplicate elements.
+// Elements are compared before conversion (after any trimming and removal of
+// quotes).
+func Unique() SliceOption {
+	return func(o *sliceOptions) {
+		o.unique = true
+	}
+}
+
+// Slice returns a function that converts a string to a slice, converting each
+// element using a specified conversion function.  Elements are separated by a
+// comma unless otherwise specified.
+//
+// An element may be enclosed in double quotes, allowing it to contain the separator
+// or leading or trailing whitespace.  Within quotes, a backslash escapes the
+// following character (e.g. \" or \\).  Quotes are removed before the element
+// is converted.
+//
+// An empty string is converted to an empty slice.
+//
+// # parameters
+//
+//	elem env.ConversionFunc[T]   // a function to convert each element
+//
+//	opts ...SliceOption          // (optional) options: Separator, TrimSpace,
+//	                             // DropEmpty, MinLen, MaxLen and Unique
+//
+// # returns
+//
+//	env.ConversionFunc[[]T]   // a function converting a string to a slice
+//
+// Slice panics with ErrEmptySeparator if an empty separator is specified.
+//
+// # errors
+//
+// Errors are returned as an env.InvalidValueError with the string being converted:
+//
+//   - an element that cannot be converted (or is a duplicate, when Unique is
+//     specified) results in an ElementError identifying the index of the element
+//     in the string (including any empty elements dropped by DropEmpty)
+//
+//   - a number of elements that is not in the range specified by MinLen and/or
+//     MaxLen results in an env.RangeError[int]
+//
+// # example
+//
+//	origins, err := env.Parse("ALLOWED_ORIGINS", as.Slice(as.AbsoluteURL, as.TrimSpace(), as.DropEmpty()))
+//	ports, err := env.Parse("PORTS", as.Slice(as.PortNo, as.Separator(";"), as.Unique()))
+func Slice[T any](elem env.ConversionFunc[T], opts ...SliceOption) env.ConversionFunc[[]T] {
+	o := &sliceOptions{separator: ",", maxLen: math.MaxInt}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.separator == "" {
+		panic(fmt.Errorf("as.Slice: %w", ErrEmptySeparator))
+	}
+
+	return func(s string) ([]T, error) {
+		invalid := func(err error) ([]T, error) {
+			return nil, env.InvalidValueError{Value: s, Err: err}
+		}
+
+		// elements are retained with their index in the string, before any empty
+		// elements are dropped, for reporting errors
+		type element struct {
+			index int
+			value string
+		}
+		elems := []element{}
+		if s != "" {
+			raw, err := split(s, o.separator, 0, "")
+			if err != nil {
+				return invalid(err)
+			}
+			for i, r := range raw {
+				v, quoted, err := unquote(r, o.trim)
+				if err != nil {
+					return invalid(ElementError{Index: i, Err: err})
+				}
+				if v == "" && !quoted && o.dropEmpty {
+					continue
+				}
+				elems = append(elems, element{i, v})
+			}
+		}
+
+		if n := len(elems); n < o.minLen || n > o.maxLen {
+			return invalid(fmt.Errorf("number of elements (%d): %w", n, env.RangeError[int]{Min: o.minLen, Max: o.maxLen}))
+		}
+
+		seen := map[string]bool{}
+		result := make([]T, 0, len(elems))
+		for _, e := range elems {
+			if o.unique {
+				if seen[e.value] {
+					return invalid(ElementError{Index: e.index, Err: ErrDuplicateValue})
+				}
+				seen[e.value] = true
+			}
+			v, err := elem(e.value)
+			if err != nil {
+				return invalid(ElementError{Index: e.index, Err: err})
+			}
+			result = append(result, v)
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,104 @@
+package as
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/blugnu/env"
+)
+
+// split splits a string into the substrings separated by a separator, ignoring any
+// separator within double quotes.  A quote is recognised only at the start of a
+// substring (ignoring leading whitespace) or, if kvSep is not empty, at the start
+// of the value following the first kvSep in a substring; a quote elsewhere is an
+// ordinary character.  The substrings are returned with any quotes retained (see
+// unquote).  A backslash within quotes escapes the following character.
+//
+// If n > 0, at most n substrings are returned; the last substring is the
+// unsplit remainder.
+//
+// # returns
+//
+//	[]string   // the substrings
+//
+//	error      // env.ErrUnterminatedQuote if a quote is not closed
+func split(s, sep string, n int, kvSep string) ([]string, error) {
+	result := []string{}
+	start, atStart, hasKV := 0, true, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], sep) && (n <= 0 || len(result) < n-1):
+			result = append(result, s[start:i])
+			start = i + len(sep)
+			i = start - 1
+			atStart, hasKV = true, false
+		case kvSep != "" && !hasKV && strings.HasPrefix(s[i:], kvSep):
+			i += len(kvSep) - 1
+			atStart, hasKV = true, true
+		case atStart && s[i] == '"':
+			if i = closingQuote(s, i); i == -1 {
+				return nil, env.ErrUnterminatedQuote
+			}
+			atStart = false
+		case atStart && unicode.IsSpace(rune(s[i])):
+		default:
+			atStart = false
+		}
+	}
+	return append(result, s[start:]), nil
+}
+
+// closingQuote returns the index of the quote closing the quote at index i in s,
+// or -1 if the quote is not closed.  A backslash escapes the following character.
+func closingQuote(s string, i int) int {
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// unquote returns the value of a substring returned by split.  If the substring
+// (ignoring surrounding whitespace) is enclosed in double quotes the quotes are
+// removed and any escaped characters (\" and \\) are replaced; otherwise the
+// substring is returned, with surrounding whitespace removed if trim is true.
+//
+// # returns
+//
+//	string   // the value
+//
+//	bool     // true if the value was quoted
+//
+//	error    // env.ErrTrailingCharacters if a quoted value is followed by
+//	         // any characters other than whitespace
+func unquote(s string, trim bool) (string, bool, error) {
+	t := strings.TrimSpace(s)
+	if !strings.HasPrefix(t, `"`) {
+		if trim {
+			return t, false, nil
+		}
+		return s, false, nil
+	}
+
+	sb := strings.Builder{}
+	for i := 1; i < len(t); i++ {
+		switch t[i] {
+		case '\\':
+			if i++; i < len(t) {
+				sb.WriteByte(t[i])
+			}
+		case '"':
+			if i != len(t)-1 {
+				return "", true, env.ErrTrailingCharacters
+			}
+			return sb.String(), true, nil
+		default:
+			sb.WriteByte(t[i])
+		}
+	}
+	return "", true, env.ErrUnterminatedQuote
+}
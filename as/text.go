@@ -0,0 +1,33 @@
+package as
+
+import "encoding"
+
+// Text converts a string to a value of any type T for which *T implements
+// encoding.TextUnmarshaler, such as netip.Addr, slog.Level, big.Int or time.Time.
+//
+// The type parameter PT is inferred and need not be specified.
+//
+// # parameters
+//
+//	s string   // the string to convert
+//
+// # returns
+//
+//	T          // the converted value
+//
+//	error      // any error returned by the UnmarshalText method of *T
+//
+// # example
+//
+//	addr, err := env.Parse("LISTEN_ADDR", as.Text[netip.Addr])
+//	level, err := env.Parse("LOG_LEVEL", as.Text[slog.Level])
+func Text[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](s string) (T, error) {
+	var v T
+	if err := PT(&v).UnmarshalText([]byte(s)); err != nil {
+		return *new(T), err
+	}
+	return v, nil
+}
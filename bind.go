@@ -0,0 +1,433 @@
+package env
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blugnu/env/internal/conv"
+)
+
+// Bind populates the fields of a struct from environment variables identified by
+// struct tags.  Each field to be populated is tagged with the name of the variable
+// and any options:
+//
+//	type Config struct {
+//		Host    string        `env:"DB_HOST,required"`
+//		Port    int           `env:"DB_PORT" default:"5432"`
+//		Timeout time.Duration `env:"DB_TIMEOUT" default:"30s"`
+//		Debug   *bool         `env:"DB_DEBUG"`
+//	}
+//
+// # parameters
+//
+//	v any            // a pointer to the struct to populate
+//
+//	opts ...Option   // (optional) options, e.g. WithPrefix
+//
+// # returns
+//
+//	error   // an error that joins all errors that occurred while binding the struct;
+//	        // if no errors occurred the result is nil
+//
+// The error for each field is a ParseError identifying the variable.  If a variable
+// cannot be converted to the type of the field, the ParseError wraps an
+// InvalidValueError with the value and the conversion error.
+//
+// If v is not a non-nil pointer to a struct, ErrInvalidTarget is returned.
+//
+// # tags
+//
+// The `env` tag identifies the variable, optionally followed by comma-separated
+// options:
+//
+//	required         // a ParseError wrapping ErrNotSet is returned if the variable
+//	                 // is not set and the field has no default
+//
+//	prefix=<p>       // for a nested struct (with no variable name), a prefix
+//	                 // added to the names of variables for fields in the struct
+//
+//	alias=<n>        // an alternative name for the variable (see Aliases)
+//
+//	deprecated=<n>   // a legacy name for the variable (see Deprecated)
+//
+//	file             // enables file indirection for the variable (see
+//	                 // WithFileIndirection)
+//
+// The alias and deprecated options may be repeated to specify more than one name.
+// Aliases and deprecated names are tried in the order specified, aliases first:
+//
+//	Proxy string `env:"HTTP_PROXY,alias=http_proxy"`
+//	DBURL string `env:"APP_DB_URL,deprecated=DATABASE_URL"`
+//
+// Any prefix is also applied to aliases and deprecated names.  The use of a
+// deprecated name is reported as specified by any OnDeprecated option.
+//
+// The `default` tag provides a value to be converted and applied to the field if
+// the variable is not set.
+//
+// If a variable is not set, is not required and the field has no default, the field
+// is not modified; a field may be initialised with a default value before calling
+// Bind, as an alternative to the `default` tag.
+//
+// Fields with no `env` tag or tagged `env:"-"` are ignored, other than nested structs.
+// Unexported fields are ignored, other than embedded structs (as for encoding/json,
+// the exported fields of an embedded struct of an unexported type are bound; a nil
+// pointer to an embedded struct of an unexported type is ignored).
+//
+// # nested structs
+//
+// A field of struct or pointer to struct type with no variable name is bound
+// recursively.  A nil pointer to a struct is set to a new struct only if a variable
+// is set for at least one field of the new struct (default values are not
+// sufficient); otherwise the pointer remains nil and any errors for fields of the
+// new struct (such as a required variable that is not set) are ignored.
+//
+// A nested struct of a type that is already being bound (a recursive type, such as
+// a Next field of type *Node in a Node struct) is ignored.
+//
+// A prefix may be specified for a nested struct, allowing the same struct type to
+// be bound to different groups of variables:
+//
+//	type Config struct {
+//		Primary DBConfig `env:",prefix=PRIMARY_"`   // e.g. PRIMARY_DB_HOST
+//		Replica DBConfig `env:",prefix=REPLICA_"`   // e.g. REPLICA_DB_HOST
+//	}
+//
+// Prefixes of nested structs are combined with the prefixes of any enclosing
+// structs and any prefix specified using the WithPrefix option:
+//
+//	err := env.Bind(&cfg, env.WithPrefix("APP_"))   // e.g. APP_PRIMARY_DB_HOST
+//
+// # supported types
+//
+// A field of a type T for which *T implements any of the following interfaces is
+// set using the first implemented interface, in the order:
+//
+//	env.Decoder                // Decode(value)
+//	encoding.TextUnmarshaler   // UnmarshalText([]byte(value)), as for as.Text
+//	json.Unmarshaler           // UnmarshalJSON([]byte(value)); the value must be JSON
+//
+// This supports types such as netip.Addr, slog.Level, big.Int and time.Time (in
+// RFC 3339 format).
+//
+// Otherwise, fields may be of the following types, or pointers to these types (a
+// pointer field is set to a new value when the variable is set):
+//
+//	string
+//	bool                                  // as for as.Bool
+//	int, int8, int16, int32, int64        // as for as.Integer
+//	uint, uint8, uint16, uint32, uint64   // as for as.Integer
+//	float32, float64                      // as for strconv.ParseFloat
+//	time.Duration                         // as for time.ParseDuration
+//	url.URL                               // as for url.Parse
+//
+// The values accepted for bool and integer fields are the same as for the as
+// conversions; an out of range integer results in an error wrapping
+// strconv.ErrRange (rather than a RangeError).
+//
+// Types derived from these types (e.g. type Level string) are also supported.  A
+// field tagged with a variable name that has any other type results in a ParseError
+// wrapping ErrUnsupportedType.
+//
+// # example
+//
+//	cfg := Config{Port: 8080}
+//	if err := env.Bind(&cfg); err != nil {
+//		log.Fatal(err)
+//	}
+func Bind(v any, opts ...Option) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", ErrInvalidTarget, v)
+	}
+
+	b := &binder{opts: newOptions(opts...), binding: map[reflect.Type]bool{}}
+	b.bindStruct(rv.Elem(), "")
+
+	return errors.Join(b.errs...)
+}
+
+// binder binds the fields of a struct to environment variables, collecting any
+// errors that occur.
+type binder struct {
+	opts    *options
+	errs    []error
+	binding map[reflect.Type]bool // the struct types currently being bound
+}
+
+// bindStruct binds the fields of a struct, returning true if a variable was set for
+// any field.
+// The prefix is added to the names of the variables bound to the fields.
+func (b *binder) bindStruct(rv reflect.Value, prefix string) bool {
+	isSet := false
+	rt := rv.Type()
+	b.binding[rt] = true
+	defer delete(b.binding, rt)
+
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		if !sf.IsExported() && !(sf.Anonymous && isNestedStruct(sf.Type)) {
+			continue
+		}
+
+		tag := sf.Tag.Get("env")
+		if tag == "-" {
+			continue
+		}
+		name, opts, err := parseTag(tag)
+		switch {
+		case err != nil:
+			b.errs = append(b.errs, ParseError{VariableName: b.opts.prefix + prefix + name, Err: err})
+		case name != "" && opts.prefix != "":
+			b.errs = append(b.errs, ParseError{VariableName: b.opts.prefix + prefix + name, Err: fmt.Errorf("%w: prefix is not valid for a named variable", ErrInvalidTag)})
+		case name != "":
+			isSet = b.bindField(rv.Field(i), prefix, name, opts, sf.Tag) || isSet
+		case isNestedStruct(sf.Type) && !b.binding[structType(sf.Type)]:
+			isSet = b.bindNested(rv.Field(i), prefix+opts.prefix) || isSet
+		}
+	}
+	return isSet
+}
+
+// bindNested binds a field of struct or pointer to struct type, returning true if
+// a variable was set for any field of the struct.
+func (b *binder) bindNested(fv reflect.Value, prefix string) bool {
+	if fv.Kind() == reflect.Struct {
+		return b.bindStruct(fv, prefix)
+	}
+	if !fv.IsNil() {
+		return b.bindStruct(fv.Elem(), prefix)
+	}
+	if !fv.CanSet() {
+		// a nil pointer embedded with an unexported type cannot be set
+		return false
+	}
+
+	// errors binding a new struct for which no variable is set (e.g. a required
+	// variable that is not set) are discarded, since the struct is not used
+	n := len(b.errs)
+	p := reflect.New(fv.Type().Elem())
+	if !b.bindStruct(p.Elem(), prefix) {
+		b.errs = b.errs[:n]
+		return false
+	}
+	fv.Set(p)
+	return true
+}
+
+// bindField sets a field from the variable with a given name (or any alias or
+// deprecated name), returning true if the variable is set (a field set from a
+// default value returns false).
+// The prefix is added to the name and to any aliases and deprecated names.
+func (b *binder) bindField(fv reflect.Value, prefix, name string, opts tagOptions, tag reflect.StructTag) bool {
+	lo := *b.opts
+	lo.aliases = prefixed(prefix, opts.aliases)
+	lo.deprecated = prefixed(prefix, opts.deprecated)
+	lo.files = lo.files || opts.file
+	name, value, isSet, err := lo.lookup(prefix + name)
+	if err != nil {
+		b.errs = append(b.errs, ParseError{VariableName: name, Err: err})
+		return false
+	}
+
+	cnv := converterFor(fv.Type())
+	if cnv == nil {
+		b.errs = append(b.errs, ParseError{VariableName: name, Err: fmt.Errorf("%w: %s", ErrUnsupportedType, fv.Type())})
+		return false
+	}
+
+	if !isSet {
+		def, hasDefault := tag.Lookup("default")
+		switch {
+		case hasDefault:
+			value = def
+		case opts.required:
+			b.errs = append(b.errs, ParseError{VariableName: name, Err: ErrNotSet})
+			return false
+		default:
+			return false
+		}
+	}
+
+	v, err := cnv(value)
+	if err != nil {
+		b.errs = append(b.errs, ParseError{VariableName: name, Err: InvalidValueError{Value: value, Err: err}})
+		return false
+	}
+	fv.Set(v)
+	return isSet
+}
+
+// prefixed returns a copy of names with a prefix added to each name.
+func prefixed(prefix string, names []string) []string {
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = prefix + name
+	}
+	return result
+}
+
+// tagOptions are the options specified in an `env` struct tag.
+type tagOptions struct {
+	required   bool
+	prefix     string
+	aliases    []string
+	deprecated []string
+	file       bool
+}
+
+// parseTag parses an `env` struct tag, returning the variable name and options.
+func parseTag(tag string) (string, tagOptions, error) {
+	name, rest, _ := strings.Cut(tag, ",")
+	name = strings.TrimSpace(name)
+
+	opts := tagOptions{}
+	if rest == "" {
+		return name, opts, nil
+	}
+	for _, opt := range strings.Split(rest, ",") {
+		opt = strings.TrimSpace(opt)
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "required":
+			opts.required = true
+		case "file":
+			opts.file = true
+		case "prefix":
+			opts.prefix = value
+		case "alias", "deprecated":
+			if value == "" {
+				return name, opts, fmt.Errorf("%w: %q: a name is required", ErrInvalidTag, opt)
+			}
+			if key == "alias" {
+				opts.aliases = append(opts.aliases, value)
+			} else {
+				opts.deprecated = append(opts.deprecated, value)
+			}
+		default:
+			return name, opts, fmt.Errorf("%w: %q", ErrInvalidTag, opt)
+		}
+	}
+	return name, opts, nil
+}
+
+var (
+	decoderType         = reflect.TypeFor[Decoder]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	urlType             = reflect.TypeFor[url.URL]()
+)
+
+// isNestedStruct returns true if a type is a struct or pointer to struct that
+// is not itself converted from a variable.
+func isNestedStruct(t reflect.Type) bool {
+	if converterFor(t) != nil {
+		return false
+	}
+	return t.Kind() == reflect.Struct || (t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct)
+}
+
+// structType returns a struct type, or the struct type of a pointer to struct.
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// converterFor returns a function converting a string to a value of a given type,
+// or nil if the type is not supported.
+func converterFor(t reflect.Type) func(string) (reflect.Value, error) {
+	if t.Kind() == reflect.Pointer {
+		cnv := converterFor(t.Elem())
+		if cnv == nil {
+			return nil
+		}
+		return func(s string) (reflect.Value, error) {
+			v, err := cnv(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			p := reflect.New(t.Elem())
+			p.Elem().Set(v)
+			return p, nil
+		}
+	}
+
+	if pt := reflect.PointerTo(t); pt.Implements(decoderType) ||
+		pt.Implements(textUnmarshalerType) ||
+		pt.Implements(jsonUnmarshalerType) {
+		return func(s string) (reflect.Value, error) {
+			p := reflect.New(t)
+			var err error
+			switch u := p.Interface().(type) {
+			case Decoder:
+				err = u.Decode(s)
+			case encoding.TextUnmarshaler:
+				err = u.UnmarshalText([]byte(s))
+			case json.Unmarshaler:
+				err = u.UnmarshalJSON([]byte(s))
+			}
+			return p.Elem(), err
+		}
+	}
+
+	switch t {
+	case durationType:
+		return func(s string) (reflect.Value, error) {
+			d, err := time.ParseDuration(s)
+			return reflect.ValueOf(d), err
+		}
+	case urlType:
+		return func(s string) (reflect.Value, error) {
+			u, err := url.Parse(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(u).Elem(), nil
+		}
+	}
+
+	// v returns a new value of type t, set using a function
+	v := func(set func(reflect.Value)) reflect.Value {
+		v := reflect.New(t).Elem()
+		set(v)
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return func(s string) (reflect.Value, error) {
+			return v(func(v reflect.Value) { v.SetString(s) }), nil
+		}
+	case reflect.Bool:
+		return func(s string) (reflect.Value, error) {
+			b, err := conv.Bool(s)
+			return v(func(v reflect.Value) { v.SetBool(b) }), err
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(s string) (reflect.Value, error) {
+			i, err := conv.Int(s, t.Bits())
+			return v(func(v reflect.Value) { v.SetInt(i) }), err
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(s string) (reflect.Value, error) {
+			u, err := conv.Uint(s, t.Bits())
+			return v(func(v reflect.Value) { v.SetUint(u) }), err
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(s string) (reflect.Value, error) {
+			f, err := strconv.ParseFloat(s, t.Bits())
+			return v(func(v reflect.Value) { v.SetFloat(f) }), err
+		}
+	}
+	return nil
+}
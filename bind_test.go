@@ -0,0 +1,584 @@
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blugnu/env/internal/conv"
+	"github.com/blugnu/test"
+)
+
+func TestBind(t *testing.T) {
+	// ARRANGE
+	type Level string
+	type config struct {
+		String   string        `env:"STRING"`
+		Bool     bool          `env:"BOOL"`
+		Int      int           `env:"INT"`
+		Int8     int8          `env:"INT8"`
+		Uint16   uint16        `env:"UINT16"`
+		Float    float64       `env:"FLOAT"`
+		Duration time.Duration `env:"DURATION"`
+		URL      url.URL       `env:"URL"`
+		Level    Level         `env:"LEVEL"`
+		PtrInt   *int          `env:"PTR_INT"`
+		PtrURL   *url.URL      `env:"PTR_URL"`
+		NotSet   *int          `env:"NOT_SET"`
+		Initial  int           `env:"INITIAL"`
+		Default  int           `env:"DEFAULT" default:"42"`
+		Untagged string
+		Ignored  string `env:"-"`
+		private  string `env:"PRIVATE"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"STRING":   "value",
+		"BOOL":     "yes",
+		"INT":      "-0_123",
+		"INT8":     "0x7F",
+		"UINT16":   "65535",
+		"FLOAT":    "1.5",
+		"DURATION": "1m30s",
+		"URL":      "http://example.com/path",
+		"LEVEL":    "debug",
+		"PTR_INT":  "7",
+		"PTR_URL":  "http://example.com",
+		"Untagged": "untagged",
+		"-":        "ignored",
+		"PRIVATE":  "private",
+	}.Set()
+	cfg := config{Initial: 99}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, cfg.String).Equals("value")
+	test.IsTrue(t, cfg.Bool)
+	test.That(t, cfg.Int).Equals(-123)
+	test.That(t, cfg.Int8).Equals(127)
+	test.That(t, cfg.Uint16).Equals(65535)
+	test.That(t, cfg.Float).Equals(1.5)
+	test.That(t, cfg.Duration).Equals(90 * time.Second)
+	test.That(t, cfg.URL).Equals(url.URL{Scheme: "http", Host: "example.com", Path: "/path"})
+	test.That(t, cfg.Level).Equals("debug")
+	test.That(t, *cfg.PtrInt).Equals(7)
+	test.That(t, cfg.PtrURL).Equals(&url.URL{Scheme: "http", Host: "example.com"})
+	test.That(t, cfg.NotSet).IsNil()
+	test.That(t, cfg.Initial).Equals(99)
+	test.That(t, cfg.Default).Equals(42)
+	test.That(t, cfg.Untagged).Equals("")
+	test.That(t, cfg.Ignored).Equals("")
+	test.That(t, cfg.private).Equals("")
+}
+
+func TestBind_NestedStructs(t *testing.T) {
+	// ARRANGE
+	type db struct {
+		Host string `env:"DB_HOST"`
+		Port int    `env:"DB_PORT" default:"5432"`
+	}
+	type cache struct {
+		Host string `env:"CACHE_HOST"`
+	}
+	type Embedded struct {
+		Name string `env:"NAME"`
+	}
+	type config struct {
+		Embedded
+		DB      db
+		Cache   *cache
+		NoCache *cache
+		Other   *struct {
+			Value string `env:"OTHER_VALUE"`
+		}
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"NAME":       "service",
+		"DB_HOST":    "db.example.com",
+		"CACHE_HOST": "cache.example.com",
+	}.Set()
+	cfg := config{NoCache: &cache{Host: "initial"}}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, cfg.Name).Equals("service")
+	test.That(t, cfg.DB).Equals(db{Host: "db.example.com", Port: 5432})
+	test.That(t, cfg.Cache).Equals(&cache{Host: "cache.example.com"})
+	test.That(t, cfg.NoCache).Equals(&cache{Host: "cache.example.com"})
+	test.That(t, cfg.Other).IsNil()
+}
+
+// embedded and embeddedPtr are unexported types embedded in a struct, to test
+// binding the exported fields of an embedded struct of an unexported type.
+type embedded struct {
+	Name string `env:"NAME"`
+}
+
+type embeddedPtr struct {
+	Value string `env:"VALUE"`
+}
+
+func TestBind_EmbeddedUnexportedStructs(t *testing.T) {
+	// ARRANGE
+	type config struct {
+		embedded
+		*embeddedPtr
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"NAME":  "name",
+		"VALUE": "value",
+	}.Set()
+
+	t.Run("nil pointer", func(t *testing.T) {
+		cfg := config{}
+
+		// ACT
+		err := Bind(&cfg)
+
+		// ASSERT
+		test.That(t, err).IsNil()
+		test.That(t, cfg.Name).Equals("name")
+		test.That(t, cfg.embeddedPtr).IsNil()
+	})
+
+	t.Run("non-nil pointer", func(t *testing.T) {
+		cfg := config{embeddedPtr: &embeddedPtr{}}
+
+		// ACT
+		err := Bind(&cfg)
+
+		// ASSERT
+		test.That(t, err).IsNil()
+		test.That(t, cfg.Name).Equals("name")
+		test.That(t, cfg.Value).Equals("value")
+	})
+}
+
+func TestBind_OptionalNestedStructs(t *testing.T) {
+	// ARRANGE
+	type db struct {
+		Host string `env:"DB_HOST,required"`
+		Port int    `env:"DB_PORT" default:"5432"`
+	}
+	type config struct {
+		Primary db  `env:",prefix=PRIMARY_"`
+		Replica *db `env:",prefix=REPLICA_"`
+		Standby *db `env:",prefix=STANDBY_"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"PRIMARY_DB_HOST": "primary.example.com",
+		"STANDBY_DB_PORT": "5433",
+	}.Set()
+	cfg := config{}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	test.Error(t, err).Is(ParseError{VariableName: "STANDBY_DB_HOST", Err: ErrNotSet})
+	test.IsFalse(t, errors.Is(err, ParseError{VariableName: "REPLICA_DB_HOST"}))
+	test.That(t, cfg.Primary).Equals(db{Host: "primary.example.com", Port: 5432})
+	test.That(t, cfg.Replica).IsNil()
+	test.That(t, cfg.Standby).Equals(&db{Port: 5433})
+}
+
+func TestBind_RecursiveStructs(t *testing.T) {
+	// ARRANGE
+	type node struct {
+		Name string `env:"NAME"`
+		Next *node
+	}
+	type config struct {
+		First  node `env:",prefix=FIRST_"`
+		Second node `env:",prefix=SECOND_"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"FIRST_NAME":  "first",
+		"SECOND_NAME": "second",
+	}.Set()
+	cfg := config{}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, cfg.First).Equals(node{Name: "first"})
+	test.That(t, cfg.Second).Equals(node{Name: "second"})
+}
+
+func TestBind_Errors(t *testing.T) {
+	// ARRANGE
+	type nested struct {
+		Port int `env:"NESTED_PORT"`
+	}
+	type config struct {
+		Required     string         `env:"REQUIRED,required"`
+		RequiredSet  string         `env:"REQUIRED_SET,required"`
+		RequiredDef  string         `env:"REQUIRED_DEFAULT,required" default:"default"`
+		Int          int            `env:"INT"`
+		Uint8        uint8          `env:"UINT8"`
+		Bool         bool           `env:"BOOL"`
+		BadDefault   int            `env:"BAD_DEFAULT" default:"forty-two"`
+		Unsupported  map[string]int `env:"UNSUPPORTED"`
+		InvalidTag   string         `env:"INVALID_TAG,requird"`
+		Nested       nested
+		UnchangedInt int `env:"UNCHANGED_INT"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"REQUIRED_SET":  "value",
+		"INT":           "not-a-number",
+		"UINT8":         "256",
+		"BOOL":          "maybe",
+		"NESTED_PORT":   "port",
+		"UNCHANGED_INT": "1.5",
+	}.Set()
+	cfg := config{UnchangedInt: 42}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	test.Error(t, err).Is(ParseError{VariableName: "REQUIRED", Err: ErrNotSet})
+	test.Error(t, err).Is(ParseError{VariableName: "INT", Err: InvalidValueError{Value: "not-a-number", Err: strconv.ErrSyntax}})
+	test.Error(t, err).Is(ParseError{VariableName: "UINT8", Err: InvalidValueError{Value: "256", Err: strconv.ErrRange}})
+	test.Error(t, err).Is(ParseError{VariableName: "BOOL", Err: InvalidValueError{Value: "maybe", Err: conv.ErrNotABool}})
+	test.Error(t, err).Is(ParseError{VariableName: "BAD_DEFAULT", Err: InvalidValueError{Value: "forty-two"}})
+	test.Error(t, err).Is(ParseError{VariableName: "UNSUPPORTED", Err: ErrUnsupportedType})
+	test.Error(t, err).Is(ParseError{VariableName: "INVALID_TAG", Err: ErrInvalidTag})
+	test.Error(t, err).Is(ParseError{VariableName: "NESTED_PORT", Err: InvalidValueError{Value: "port"}})
+	test.Error(t, err).Is(ParseError{VariableName: "UNCHANGED_INT"})
+	test.That(t, len(err.(interface{ Unwrap() []error }).Unwrap())).Equals(9)
+	test.That(t, cfg.RequiredSet).Equals("value")
+	test.That(t, cfg.RequiredDef).Equals("default")
+	test.That(t, cfg.UnchangedInt).Equals(42)
+}
+
+func TestBind_InvalidTarget(t *testing.T) {
+	// ARRANGE
+	type config struct{}
+	testcases := []struct {
+		scenario string
+		target   any
+	}{
+		{scenario: "nil", target: nil},
+		{scenario: "struct", target: config{}},
+		{scenario: "nil pointer", target: (*config)(nil)},
+		{scenario: "pointer to non-struct", target: new(int)},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			err := Bind(tc.target)
+
+			// ASSERT
+			test.Error(t, err).Is(ErrInvalidTarget)
+		})
+	}
+}
+
+func TestBind_WhenURLIsInvalid(t *testing.T) {
+	// ARRANGE
+	type config struct {
+		URL *url.URL `env:"URL"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("URL", "http://[::1")
+	cfg := config{}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	var urlerr *url.Error
+	test.IsTrue(t, errors.As(err, &urlerr))
+	test.That(t, cfg.URL).IsNil()
+}
+
+func TestBind_WithPrefix(t *testing.T) {
+	// ARRANGE
+	type db struct {
+		Host string `env:"DB_HOST"`
+		Port int    `env:"DB_PORT" default:"5432"`
+	}
+	type config struct {
+		Name    string `env:"NAME"`
+		Primary db     `env:",prefix=PRIMARY_"`
+		Replica *db    `env:",prefix=REPLICA_"`
+		Other   db
+	}
+	testcases := []struct {
+		scenario string
+		opts     []Option
+		vars     Vars
+		result   config
+	}{
+		{scenario: "nested prefixes",
+			vars: Vars{
+				"NAME":            "service",
+				"PRIMARY_DB_HOST": "primary.example.com",
+				"REPLICA_DB_HOST": "replica.example.com",
+				"REPLICA_DB_PORT": "5433",
+				"DB_HOST":         "other.example.com",
+			},
+			result: config{
+				Name:    "service",
+				Primary: db{Host: "primary.example.com", Port: 5432},
+				Replica: &db{Host: "replica.example.com", Port: 5433},
+				Other:   db{Host: "other.example.com", Port: 5432},
+			},
+		},
+		{scenario: "WithPrefix and nested prefixes",
+			opts: []Option{WithPrefix("APP_")},
+			vars: Vars{
+				"APP_NAME":            "service",
+				"APP_PRIMARY_DB_HOST": "primary.example.com",
+				"APP_REPLICA_DB_PORT": "5433",
+				"PRIMARY_DB_HOST":     "not-used",
+			},
+			result: config{
+				Name:    "service",
+				Primary: db{Host: "primary.example.com", Port: 5432},
+				Replica: &db{Port: 5433},
+				Other:   db{Port: 5432},
+			},
+		},
+		{scenario: "combined WithPrefix options",
+			opts: []Option{WithPrefix("APP_"), WithPrefix("V2_")},
+			vars: Vars{
+				"APP_V2_NAME": "service",
+			},
+			result: config{
+				Name:    "service",
+				Primary: db{Port: 5432},
+				Other:   db{Port: 5432},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ARRANGE
+			defer State().Reset()
+			os.Clearenv()
+			tc.vars.Set()
+			cfg := config{}
+
+			// ACT
+			err := Bind(&cfg, tc.opts...)
+
+			// ASSERT
+			test.That(t, err).IsNil()
+			test.That(t, cfg).Equals(tc.result)
+		})
+	}
+}
+
+func TestBind_WithPrefix_Errors(t *testing.T) {
+	// ARRANGE
+	type db struct {
+		Port int `env:"DB_PORT,required"`
+	}
+	type config struct {
+		Replica db     `env:",prefix=REPLICA_"`
+		Invalid string `env:"NAME,prefix=X_"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+
+	// ACT
+	err := Bind(&config{}, WithPrefix("APP_"))
+
+	// ASSERT
+	test.Error(t, err).Is(ParseError{VariableName: "APP_REPLICA_DB_PORT", Err: ErrNotSet})
+	test.Error(t, err).Is(ParseError{VariableName: "APP_NAME", Err: ErrInvalidTag})
+}
+
+// hosts is a type implementing Decoder, for testing Bind
+type hosts []string
+
+func (h *hosts) Decode(value string) error {
+	if value == "" {
+		return errors.New("no hosts")
+	}
+	*h = strings.Split(value, ",")
+	return nil
+}
+
+// decoderAndText is a type implementing both Decoder and encoding.TextUnmarshaler,
+// for testing Bind
+type decoderAndText string
+
+func (d *decoderAndText) Decode(value string) error {
+	*d = decoderAndText("decoded:" + value)
+	return nil
+}
+
+func (d *decoderAndText) UnmarshalText(text []byte) error {
+	*d = decoderAndText("text:" + string(text))
+	return nil
+}
+
+func TestBind_Decoders(t *testing.T) {
+	// ARRANGE
+	type config struct {
+		Hosts    hosts           `env:"HOSTS"`
+		Both     decoderAndText  `env:"BOTH"`
+		Addr     netip.Addr      `env:"ADDR"`
+		AddrPtr  *netip.Addr     `env:"ADDR"`
+		Level    slog.Level      `env:"LEVEL"`
+		Big      *big.Int        `env:"BIG"`
+		Time     time.Time       `env:"TIME"`
+		Settings json.RawMessage `env:"SETTINGS"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"HOSTS":    "a.example.com,b.example.com",
+		"BOTH":     "value",
+		"ADDR":     "10.0.0.1",
+		"LEVEL":    "debug",
+		"BIG":      "123456789012345678901234567890",
+		"TIME":     "2024-06-01T12:00:00Z",
+		"SETTINGS": `{"retries":3}`,
+	}.Set()
+	cfg := config{}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.Slice(t, cfg.Hosts).Equals([]string{"a.example.com", "b.example.com"})
+	test.That(t, cfg.Both).Equals("decoded:value")
+	test.That(t, cfg.Addr).Equals(netip.MustParseAddr("10.0.0.1"))
+	test.That(t, *cfg.AddrPtr).Equals(netip.MustParseAddr("10.0.0.1"))
+	test.That(t, cfg.Level).Equals(slog.LevelDebug)
+	test.That(t, cfg.Big.String()).Equals("123456789012345678901234567890")
+	test.That(t, cfg.Time).Equals(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	test.That(t, string(cfg.Settings)).Equals(`{"retries":3}`)
+}
+
+func TestBind_Decoders_Errors(t *testing.T) {
+	// ARRANGE
+	type config struct {
+		Hosts hosts      `env:"HOSTS"`
+		Addr  netip.Addr `env:"ADDR"`
+		JSON  *jsonValue `env:"JSON"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"HOSTS": "",
+		"ADDR":  "not-an-address",
+		"JSON":  "not-json",
+	}.Set()
+	cfg := config{}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	test.Error(t, err).Is(ParseError{VariableName: "HOSTS", Err: InvalidValueError{}})
+	test.Error(t, err).Is(ParseError{VariableName: "ADDR", Err: InvalidValueError{Value: "not-an-address"}})
+	test.Error(t, err).Is(ParseError{VariableName: "JSON", Err: InvalidValueError{Value: "not-json"}})
+	test.That(t, cfg.JSON).IsNil()
+}
+
+// jsonValue is a type implementing json.Unmarshaler, for testing Bind
+type jsonValue struct {
+	Retries int `json:"retries"`
+}
+
+func (j *jsonValue) UnmarshalJSON(b []byte) error {
+	type plain jsonValue
+	return json.Unmarshal(b, (*plain)(j))
+}
+
+func TestBind_AliasesAndDeprecatedNames(t *testing.T) {
+	// ARRANGE
+	type db struct {
+		URL string `env:"DB_URL,deprecated=DATABASE_URL"`
+	}
+	type config struct {
+		Proxy  string `env:"HTTP_PROXY,alias=http_proxy,alias=https_proxy"`
+		Port   int    `env:"SERVICE_PORT,alias=PORT"`
+		DB     db     `env:",prefix=APP_"`
+		BadTag string `env:"BAD_TAG,alias="`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"https_proxy":      "http://proxy",
+		"PORT":             "port",
+		"APP_DATABASE_URL": "postgres://db",
+	}.Set()
+	deprecated := map[string]string{}
+	onDeprecated := func(used, preferred string) { deprecated[used] = preferred }
+	cfg := config{}
+
+	// ACT
+	err := Bind(&cfg, OnDeprecated(onDeprecated))
+
+	// ASSERT
+	test.Error(t, err).Is(ParseError{VariableName: "PORT", Err: InvalidValueError{Value: "port"}})
+	test.Error(t, err).Is(ParseError{VariableName: "BAD_TAG", Err: ErrInvalidTag})
+	test.That(t, cfg.Proxy).Equals("http://proxy")
+	test.That(t, cfg.DB.URL).Equals("postgres://db")
+	test.Map(t, deprecated).Equals(map[string]string{"APP_DATABASE_URL": "APP_DB_URL"})
+}
+
+func TestBind_FileIndirection(t *testing.T) {
+	// ARRANGE
+	type config struct {
+		Password string `env:"DB_PASSWORD,file"`
+		APIKey   string `env:"API_KEY"`
+		User     string `env:"DB_USER,file"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	Vars{
+		"DB_PASSWORD_FILE": "/run/secrets/db_password",
+		"API_KEY_FILE":     "/run/secrets/api_key",
+		"DB_USER":          "user",
+		"DB_USER_FILE":     "/run/secrets/db_user",
+	}.Set()
+	defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+		return fakeFile(strings.TrimPrefix(path, "/run/secrets/") + "\n"), nil
+	})()
+	cfg := config{}
+
+	// ACT
+	err := Bind(&cfg)
+
+	// ASSERT
+	test.Error(t, err).Is(ParseError{VariableName: "DB_USER", Err: ErrAmbiguousVariable})
+	test.That(t, cfg.Password).Equals("db_password")
+	test.That(t, cfg.APIKey).Equals("")
+	test.That(t, cfg.User).Equals("")
+}
@@ -0,0 +1,18 @@
+package env
+
+// Decoder is implemented by types that decode their value from the value of an
+// environment variable.  Bind uses the Decode method of a field of a type that
+// implements Decoder (with a pointer receiver) in preference to any other
+// conversion.
+//
+// # example
+//
+//	type Hosts []string
+//
+//	func (h *Hosts) Decode(value string) error {
+//		*h = strings.Split(value, ",")
+//		return nil
+//	}
+type Decoder interface {
+	Decode(value string) error
+}
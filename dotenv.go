@@ -0,0 +1,223 @@
+package env
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// entry is a variable read from a .env file.
+type entry struct {
+	name  string
+	value string
+	line  int
+}
+
+// readEntries reads the variables from .env formatted content.  Lines that are
+// empty or start with a hash (#) are ignored.
+//
+// A quoted value may span multiple lines; the value continues until the closing
+// quote, with the line breaks retained in the value.
+//
+// # parameters
+//
+//	path string   // the path of the file from which the content is read; used to
+//	              // identify the file in any SyntaxError (may be empty)
+//
+//	r io.Reader   // the content to read
+//
+// # returns
+//
+//	[]entry   // the variables read, in the order they appear in the content
+//
+//	error     // any error reading the content; malformed entries are reported
+//	          // as a SyntaxError identifying the line on which the entry starts
+//
+// An error in one variable does not prevent the remaining variables from being
+// read; the entries for all valid variables are returned with any errors joined.
+func readEntries(path string, r io.Reader) ([]entry, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	entries := []entry{}
+	errs := []error{}
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		raw := lines[i]
+		line := strings.TrimSpace(raw)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		indent := strings.Index(raw, line)
+		syntaxError := func(offset int, err error) error {
+			return SyntaxError{
+				File:   path,
+				Line:   lineNo,
+				Column: utf8.RuneCountInString(raw[:offset]) + 1,
+				Text:   line,
+				Err:    err,
+			}
+		}
+
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			errs = append(errs, syntaxError(indent+len(line), ErrMissingEquals))
+			continue
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			errs = append(errs, syntaxError(indent, ErrMissingName))
+			continue
+		}
+		if n := invalidNameChar(name); n != -1 {
+			errs = append(errs, syntaxError(indent+n, ErrInvalidName))
+			continue
+		}
+		valueOffset := indent + strings.Index(line, "=") + 1
+		valueOffset += len(value) - len(strings.TrimLeftFunc(value, unicode.IsSpace))
+		value = strings.TrimSpace(value)
+
+		// a quoted value that is not closed on this line continues on the next
+		if value != "" && (value[0] == '"' || value[0] == '\'') {
+			for closingQuote(value, value[0]) == -1 && i+1 < len(lines) {
+				i++
+				value += "\n" + lines[i]
+			}
+		}
+
+		value, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			errs = append(errs, syntaxError(valueOffset, err))
+			continue
+		}
+		entries = append(entries, entry{name: name, value: value, line: lineNo})
+	}
+	return entries, errors.Join(errs...)
+}
+
+// invalidNameChar returns the index of the first character in a name that is
+// not valid, or -1 if the name is valid.  A valid name consists of letters,
+// digits and underscores and does not start with a digit.
+func invalidNameChar(name string) int {
+	for i := 0; i < len(name); i++ {
+		if !isNameChar(name[i]) || (i == 0 && !isNameStart(name[i])) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseValue returns the value of a variable from the text following the '='
+// in a .env file entry.  The text is expected to have been trimmed of leading
+// and trailing whitespace.
+//
+// Values are interpreted according to the de-facto .env quoting rules:
+//
+//   - double-quoted values may contain escape sequences (\n, \r, \t, \", \\
+//     and \$); any other escaped character is retained as-is, including the
+//     backslash
+//   - single-quoted values are literal; no escape sequences are recognised
+//     and no variable references are expanded
+//   - unquoted values are taken as-is, up to any comment introduced by a
+//     hash (#) preceded by whitespace
+//
+// The enclosing quotes of a quoted value are removed.  A quoted value may be
+// followed by whitespace and/or a comment but no other characters.
+//
+// The value is returned as a template to be expanded (see: expand).  Any
+// literal '$' in the value (in a single-quoted value or escaped as \$ in a
+// double-quoted value) is returned as "$$".
+//
+// # returns
+//
+//	string   // the value, as an expansion template
+//
+//	error    // ErrUnterminatedQuote if a quoted value has no closing quote;
+//	         // ErrTrailingCharacters if characters follow a closing quote
+func parseValue(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	switch q := s[0]; q {
+	case '"', '\'':
+		end := closingQuote(s, q)
+		if end == -1 {
+			return "", ErrUnterminatedQuote
+		}
+		if rest := strings.TrimSpace(s[end+1:]); rest != "" && rest[0] != '#' {
+			return "", ErrTrailingCharacters
+		}
+		if q == '\'' {
+			return strings.ReplaceAll(s[1:end], "$", "$$"), nil
+		}
+		return unescape(s[1:end]), nil
+
+	default:
+		for i := 1; i < len(s); i++ {
+			if s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t') {
+				return strings.TrimSpace(s[:i]), nil
+			}
+		}
+		return s, nil
+	}
+}
+
+// closingQuote returns the index of the quote character that closes a value
+// opened by the quote at s[0], or -1 if the value is not closed.  Within a
+// double-quoted value a quote escaped with a backslash does not close the
+// value.
+func closingQuote(s string, q byte) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && q == '"':
+			i++
+		case s[i] == q:
+			return i
+		}
+	}
+	return -1
+}
+
+// unescape replaces the escape sequences recognised in a double-quoted value
+// with the characters they represent.  An escaped '$' is replaced with "$$" to
+// prevent it being expanded.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case '"', '\\':
+			sb.WriteByte(s[i])
+		case '$':
+			sb.WriteString("$$")
+		default:
+			sb.WriteByte('\\')
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
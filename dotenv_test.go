@@ -0,0 +1,153 @@
+package env
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blugnu/test"
+)
+
+func TestParseValue(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		input    string
+		result   string
+		err      error
+	}{
+		{scenario: "empty", input: "", result: ""},
+		{scenario: "unquoted", input: "hello world", result: "hello world"},
+		{scenario: "unquoted/with comment", input: "hello world # comment", result: "hello world"},
+		{scenario: "unquoted/with hash", input: "hello#world", result: "hello#world"},
+		{scenario: "unquoted/with quotes", input: `hello "world"`, result: `hello "world"`},
+		{scenario: "unquoted/with backslash", input: `hello\nworld`, result: `hello\nworld`},
+		{scenario: "double-quoted", input: `"hello world"`, result: "hello world"},
+		{scenario: "double-quoted/empty", input: `""`, result: ""},
+		{scenario: "double-quoted/escapes", input: `"a\nb\rc\td\"e\\f\$g"`, result: "a\nb\rc\td\"e\\f$$g"},
+		{scenario: "double-quoted/unrecognised escape", input: `"a\qb"`, result: `a\qb`},
+		{scenario: "double-quoted/with hash", input: `"hello # world"`, result: "hello # world"},
+		{scenario: "double-quoted/with single quote", input: `"it's"`, result: "it's"},
+		{scenario: "double-quoted/with comment", input: `"hello" # comment`, result: "hello"},
+		{scenario: "double-quoted/unterminated", input: `"hello`, err: ErrUnterminatedQuote},
+		{scenario: "double-quoted/escaped closing quote", input: `"hello\"`, err: ErrUnterminatedQuote},
+		{scenario: "double-quoted/trailing characters", input: `"hello" world`, err: ErrTrailingCharacters},
+		{scenario: "single-quoted", input: `'hello world'`, result: "hello world"},
+		{scenario: "single-quoted/literal", input: `'a\nb\"c'`, result: `a\nb\"c`},
+		{scenario: "single-quoted/with dollar", input: `'$HOME'`, result: "$$HOME"},
+		{scenario: "single-quoted/with double quote", input: `'say "hi"'`, result: `say "hi"`},
+		{scenario: "single-quoted/with comment", input: `'hello'   # comment`, result: "hello"},
+		{scenario: "single-quoted/unterminated", input: `'hello`, err: ErrUnterminatedQuote},
+		{scenario: "single-quoted/trailing characters", input: `'hello'world`, err: ErrTrailingCharacters},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := parseValue(tc.input)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestReadEntries(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		content  string
+		result   []entry
+		err      error
+	}{
+		{scenario: "empty",
+			content: "",
+			result:  []entry{},
+		},
+		{scenario: "comments and empty lines",
+			content: "# comment\n\n  \nVAR=value\n  # indented comment",
+			result:  []entry{{name: "VAR", value: "value", line: 4}},
+		},
+		{scenario: "whitespace around name and value",
+			content: "  VAR  =  value  ",
+			result:  []entry{{name: "VAR", value: "value", line: 1}},
+		},
+		{scenario: "CRLF line endings",
+			content: "VAR1=value1\r\nVAR2=\"value2\"\r\n",
+			result: []entry{
+				{name: "VAR1", value: "value1", line: 1},
+				{name: "VAR2", value: "value2", line: 2},
+			},
+		},
+		{scenario: "multi-line double-quoted value",
+			content: "VAR1=\"line1\n  line2\nline3\"\nVAR2=value",
+			result: []entry{
+				{name: "VAR1", value: "line1\n  line2\nline3", line: 1},
+				{name: "VAR2", value: "value", line: 4},
+			},
+		},
+		{scenario: "multi-line single-quoted value",
+			content: "VAR1='{\n  \"key\": \"value\"\n}' # json\nVAR2=value",
+			result: []entry{
+				{name: "VAR1", value: "{\n  \"key\": \"value\"\n}", line: 1},
+				{name: "VAR2", value: "value", line: 4},
+			},
+		},
+		{scenario: "multi-line value with escaped quote",
+			content: "VAR=\"line1 \\\"\nline2\"",
+			result:  []entry{{name: "VAR", value: "line1 \"\nline2", line: 1}},
+		},
+		{scenario: "unterminated multi-line value",
+			content: "VAR1=value\nVAR2=\"line1\nline2",
+			result:  []entry{{name: "VAR1", value: "value", line: 1}},
+			err:     SyntaxError{File: "test.env", Line: 2, Column: 6, Text: `VAR2="line1`, Err: ErrUnterminatedQuote},
+		},
+		{scenario: "missing equals",
+			content: "VAR1=value\n  VAR2  \nVAR3=value",
+			result: []entry{
+				{name: "VAR1", value: "value", line: 1},
+				{name: "VAR3", value: "value", line: 3},
+			},
+			err: SyntaxError{File: "test.env", Line: 2, Column: 7, Text: "VAR2", Err: ErrMissingEquals},
+		},
+		{scenario: "missing name",
+			content: "  =value",
+			result:  []entry{},
+			err:     SyntaxError{Line: 1, Column: 3, Text: "=value", Err: ErrMissingName},
+		},
+		{scenario: "invalid name/character",
+			content: "MY-VAR=value",
+			result:  []entry{},
+			err:     SyntaxError{Line: 1, Column: 3, Text: "MY-VAR=value", Err: ErrInvalidName},
+		},
+		{scenario: "invalid name/leading digit",
+			content: "1VAR=value",
+			result:  []entry{},
+			err:     SyntaxError{Line: 1, Column: 1, Err: ErrInvalidName},
+		},
+		{scenario: "invalid name/whitespace",
+			content: "MY VAR=value",
+			result:  []entry{},
+			err:     SyntaxError{Line: 1, Column: 3, Err: ErrInvalidName},
+		},
+		{scenario: "trailing characters",
+			content: "VAR= 'value' extra",
+			result:  []entry{},
+			err:     SyntaxError{Line: 1, Column: 6, Err: ErrTrailingCharacters},
+		},
+		{scenario: "multiple errors",
+			content: "VAR1\nVAR2=ok\n-VAR3=value",
+			result:  []entry{{name: "VAR2", value: "ok", line: 2}},
+			err:     SyntaxError{Line: 3, Err: ErrInvalidName},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := readEntries("test.env", strings.NewReader(tc.content))
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.Slice(t, result).Equals(tc.result)
+		})
+	}
+}
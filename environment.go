@@ -0,0 +1,172 @@
+package env
+
+import (
+	"maps"
+	"os"
+	"sync"
+)
+
+// Default is an Environment backed by the process environment.  Code that takes an
+// *Environment dependency may be provided with Default in production and with an
+// isolated Environment (see NewEnvironment) in tests.
+var Default = &Environment{isOS: true}
+
+// Environment is a set of environment variables.  An Environment is either backed
+// by the process environment (Default) or held in memory, isolated from the process
+// environment and from other Environments.
+//
+// An Environment is safe for concurrent use.  Tests using an in-memory Environment,
+// rather than modifying the process environment, may be run in parallel.
+//
+// The zero value is an empty, in-memory Environment.
+//
+// An Environment is a Source; variables are parsed from an Environment using the
+// From option:
+//
+//	func configure(e *env.Environment) (int, error) {
+//		return env.Parse("PORT", as.PortNo, env.From(e))
+//	}
+//
+//	port, err := configure(env.Default)   // in production
+//
+//	e := env.NewEnvironment(env.Vars{"PORT": "8080"})
+//	port, err := configure(e)             // in a test
+type Environment struct {
+	mu   sync.RWMutex
+	isOS bool
+	vars Vars
+}
+
+// NewEnvironment returns an in-memory Environment initialised with a copy of the
+// specified variables.
+//
+// # parameters
+//
+//	vars Vars   // the initial variables; may be nil
+//
+// # returns
+//
+//	*Environment   // a new Environment
+func NewEnvironment(vars Vars) *Environment {
+	return &Environment{vars: maps.Clone(vars)}
+}
+
+// Clear removes all variables from the Environment.
+func (e *Environment) Clear() {
+	if e.isOS {
+		os.Clearenv()
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.vars = nil
+}
+
+// Get returns the value of the variable with the given name.  If the variable is
+// not set an empty string is returned.
+func (e *Environment) Get(name string) string {
+	v, _ := e.Lookup(name)
+	return v
+}
+
+// GetVars returns a map of the variables in the Environment.  If no variable names
+// are provided all variables are returned.  If variable names are provided, only
+// those variables are returned (if set).
+func (e *Environment) GetVars(names ...string) Vars {
+	if e.isOS {
+		return GetVars(names...)
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if len(names) == 0 {
+		result := maps.Clone(e.vars)
+		if result == nil {
+			result = Vars{}
+		}
+		return result
+	}
+	result := make(Vars, len(names))
+	for _, k := range names {
+		if v, ok := e.vars[k]; ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Lookup returns the value of the variable with the given name and true if the
+// variable is set, otherwise an empty string and false.
+func (e *Environment) Lookup(name string) (string, bool) {
+	if e.isOS {
+		return osLookupEnv(name)
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	v, ok := e.vars[name]
+	return v, ok
+}
+
+// Names returns the names of the variables in the Environment, sorted by name.
+// With Lookup, Names implements the Source interface.
+func (e *Environment) Names() []string {
+	return e.GetVars().Names()
+}
+
+// Set sets the value of the variable with the given name.
+//
+// # returns
+//
+//	error   // any error that occurs while setting the variable; an in-memory
+//	        // Environment always returns nil
+func (e *Environment) Set(name, value string) error {
+	if e.isOS {
+		return osSetenv(name, value)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.vars == nil {
+		e.vars = Vars{}
+	}
+	e.vars[name] = value
+	return nil
+}
+
+// Unset removes the variables with the given names.  If a variable is not set it
+// is ignored.
+//
+// # returns
+//
+//	error   // any error that occurs while unsetting the variables; an in-memory
+//	        // Environment always returns nil
+func (e *Environment) Unset(names ...string) error {
+	if e.isOS {
+		return Unset(names...)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, k := range names {
+		delete(e.vars, k)
+	}
+	return nil
+}
+
+// Load loads variables from one or more files into the Environment, according to
+// the same rules as for Load.
+func (e *Environment) Load(files ...string) error {
+	return newLoader(into(e)).load(files)
+}
+
+// LoadWithOptions loads variables from one or more files into the Environment, with
+// options, according to the same rules as for LoadWithOptions.
+func (e *Environment) LoadWithOptions(files []string, opts ...LoadOption) error {
+	return newLoader(append([]LoadOption{into(e)}, opts...)...).load(files)
+}
+
+// into returns a LoadOption that loads variables into an Environment.
+func into(e *Environment) LoadOption {
+	return func(l *loader) {
+		l.lookupEnv = e.Lookup
+		l.setenv = e.Set
+	}
+}
@@ -0,0 +1,138 @@
+package env
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/blugnu/test"
+)
+
+func TestEnvironment(t *testing.T) {
+	// ARRANGE
+	t.Parallel()
+	initial := Vars{"A": "a", "B": "b"}
+	e := NewEnvironment(initial)
+
+	// ACT
+	err1 := e.Set("C", "c")
+	err2 := e.Unset("A", "NOT_SET")
+	c, isSetC := e.Lookup("C")
+	_, isSetA := e.Lookup("A")
+
+	// ASSERT
+	test.That(t, err1).IsNil()
+	test.That(t, err2).IsNil()
+	test.That(t, c).Equals("c")
+	test.IsTrue(t, isSetC)
+	test.IsFalse(t, isSetA)
+	test.That(t, e.Get("B")).Equals("b")
+	test.That(t, e.Get("A")).Equals("")
+	test.Map(t, e.GetVars()).Equals(Vars{"B": "b", "C": "c"})
+	test.Map(t, e.GetVars("B", "NOT_SET")).Equals(Vars{"B": "b"})
+	test.Slice(t, e.Names()).Equals([]string{"B", "C"})
+	test.Map(t, initial).Equals(Vars{"A": "a", "B": "b"})
+
+	t.Run("clear", func(t *testing.T) {
+		// ACT
+		e.Clear()
+
+		// ASSERT
+		test.Map(t, e.GetVars()).Equals(Vars{})
+	})
+}
+
+func TestEnvironment_ZeroValue(t *testing.T) {
+	// ARRANGE
+	t.Parallel()
+	e := &Environment{}
+
+	// ACT
+	err := e.Set("A", "a")
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.Map(t, e.GetVars()).Equals(Vars{"A": "a"})
+}
+
+func TestEnvironment_Parse(t *testing.T) {
+	// ARRANGE
+	t.Parallel()
+	e := NewEnvironment(Vars{"PORT": "8080"})
+
+	// ACT
+	port, err := Parse("PORT", strconv.Atoi, From(e))
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, port).Equals(8080)
+}
+
+func TestEnvironment_Load(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("HOST", "os.example.com")
+	defer test.Using(&newFileReader, func(string) (fileReader, error) {
+		return fakeFile("PORT=8080\nURL=http://${HOST}:${PORT}"), nil
+	})()
+	e := NewEnvironment(Vars{"HOST": "example.com", "PORT": "80"})
+
+	// ACT
+	err := e.LoadWithOptions([]string{"test.env"}, WithOverrideMode(OverrideNever))
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.Map(t, e.GetVars()).Equals(Vars{"HOST": "example.com", "PORT": "80", "URL": "http://example.com:80"})
+	test.Map(t, GetVars()).Equals(Vars{"HOST": "os.example.com"})
+}
+
+func TestEnvironment_Concurrency(t *testing.T) {
+	// ARRANGE
+	t.Parallel()
+	e := NewEnvironment(nil)
+	wg := sync.WaitGroup{}
+
+	// ACT
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := "VAR" + strconv.Itoa(i)
+			_ = e.Set(name, "value")
+			_, _ = e.Lookup(name)
+			_ = e.GetVars()
+		}()
+	}
+	wg.Wait()
+
+	// ASSERT
+	test.That(t, len(e.Names())).Equals(100)
+}
+
+func TestDefault(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("A", "a")
+
+	// ACT
+	err1 := Default.Set("B", "b")
+	err2 := Default.Unset("A")
+
+	// ASSERT
+	test.That(t, err1).IsNil()
+	test.That(t, err2).IsNil()
+	test.That(t, os.Getenv("B")).Equals("b")
+	test.Map(t, Default.GetVars()).Equals(Vars{"B": "b"})
+	test.Slice(t, Default.Names()).Equals([]string{"B"})
+
+	t.Run("clear", func(t *testing.T) {
+		// ACT
+		Default.Clear()
+
+		// ASSERT
+		test.Map(t, GetVars()).Equals(Vars{})
+	})
+}
@@ -3,11 +3,27 @@ package env
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
 )
 
 var (
+	ErrAmbiguousVariable = errors.New("ambiguous variable")
 	ErrNotSet            = errors.New("not set")
 	ErrSetVariableFailed = errors.New("set variable failed")
+
+	ErrInvalidTag      = errors.New("invalid tag")
+	ErrInvalidTarget   = errors.New("invalid target: must be a non-nil pointer to a struct")
+	ErrUnsupportedType = errors.New("unsupported type")
+
+	ErrCyclicReference    = errors.New("cyclic reference")
+	ErrInvalidName        = errors.New("invalid variable name")
+	ErrInvalidReference   = errors.New("invalid reference")
+	ErrMissingEquals      = errors.New("missing '='")
+	ErrMissingName        = errors.New("missing variable name")
+	ErrTrailingCharacters = errors.New("unexpected characters after closing quote")
+	ErrUnterminatedQuote  = errors.New("unterminated quoted value")
 )
 
 // ParseError is an error that wraps an error occurring while
@@ -65,6 +81,52 @@ func (e ParseError) Unwrap() error {
 	return e.Err
 }
 
+// FileNotFoundError is an error that identifies a file that was not found when
+// searching a number of directories.  Path is the (relative) path of the file and
+// Searched identifies the directories that were searched, in the order searched.
+//
+// A FileNotFoundError satisfies errors.Is(err, fs.ErrNotExist).
+type FileNotFoundError struct {
+	Path     string
+	Searched []string
+}
+
+// Error returns a string representation of the error in the form:
+//
+//	env.FileNotFoundError: searched: <dir>, <dir>, ...
+//
+// If the Searched field is empty:
+//
+//	env.FileNotFoundError
+//
+// The Path field is not included; errors returned by Load are wrapped with the
+// file path.
+func (e FileNotFoundError) Error() string {
+	if len(e.Searched) == 0 {
+		return "env.FileNotFoundError"
+	}
+	return "env.FileNotFoundError: searched: " + strings.Join(e.Searched, ", ")
+}
+
+// Is reports whether the target error is a match for the receiver.
+// To be a match, the target must:
+//
+//   - be a FileNotFoundError
+//   - the target Path field must match the receiver's Path, or be empty
+//   - the target Searched field must match the receiver's Searched, or be empty
+func (e FileNotFoundError) Is(target error) bool {
+	if target, ok := target.(FileNotFoundError); ok {
+		return (target.Path == "" || e.Path == target.Path) &&
+			(len(target.Searched) == 0 || slices.Equal(e.Searched, target.Searched))
+	}
+	return false
+}
+
+// Unwrap returns fs.ErrNotExist.
+func (e FileNotFoundError) Unwrap() error {
+	return fs.ErrNotExist
+}
+
 // InvalidValueError is an error type that represents an invalid value.  The Value
 // field contains the invalid value, and the Err field contains the error that
 // caused the value to be invalid.
@@ -119,6 +181,69 @@ func (e InvalidValueError) Unwrap() error {
 	return e.Err
 }
 
+// SyntaxError is an error that identifies a malformed entry in a .env file.
+// File, Line and Column identify the location of the error, with Line and
+// Column numbered from 1.  Text is the (trimmed) text of the line on which
+// the error occurs and Err identifies the reason for the error, e.g.
+// ErrMissingEquals or ErrUnterminatedQuote.
+//
+// If the content was not read from a file, File is empty.
+type SyntaxError struct {
+	File   string
+	Line   int
+	Column int
+	Text   string
+	Err    error
+}
+
+// Error returns a string representation of the error in the form:
+//
+//	env.SyntaxError: line <line>, column <column>: <error>: "<text>"
+//
+// Any field that is empty (or zero) is omitted from the string.  The File field
+// is not included; errors returned by Load are wrapped with the file path.
+func (e SyntaxError) Error() string {
+	sb := strings.Builder{}
+	sb.WriteString("env.SyntaxError")
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, ": line %d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&sb, ", column %d", e.Column)
+		}
+	}
+	if e.Err != nil {
+		sb.WriteString(": " + e.Err.Error())
+	}
+	if e.Text != "" {
+		fmt.Fprintf(&sb, ": %q", e.Text)
+	}
+	return sb.String()
+}
+
+// Is reports whether the target error is a match for the receiver.
+// To be a match, the target must:
+//
+//   - be a SyntaxError
+//   - the target File, Line, Column and Text fields must match the
+//     corresponding fields of the receiver, or be empty (or zero)
+//   - the target Err field must satisfy errors.Is with respect to the
+//     receiver Err, or be nil
+func (e SyntaxError) Is(target error) bool {
+	if target, ok := target.(SyntaxError); ok {
+		return (target.File == "" || e.File == target.File) &&
+			(target.Line == 0 || e.Line == target.Line) &&
+			(target.Column == 0 || e.Column == target.Column) &&
+			(target.Text == "" || e.Text == target.Text) &&
+			(target.Err == nil || errors.Is(e.Err, target.Err))
+	}
+	return false
+}
+
+// Unwrap returns the error that identifies the reason for the syntax error.
+func (e SyntaxError) Unwrap() error {
+	return e.Err
+}
+
 // RangeError is an error type that represents a value that is out of range; Min and
 // Max fields identify the range of valid values.
 //
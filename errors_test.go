@@ -2,6 +2,7 @@ package env
 
 import (
 	"errors"
+	"io/fs"
 	"testing"
 
 	"github.com/blugnu/test"
@@ -124,6 +125,59 @@ func TestParseError_Unwrap(t *testing.T) {
 	test.Value(t, result).Equals(sut.Err)
 }
 
+func TestFileNotFoundError_Error(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		sut      FileNotFoundError
+		result   string
+	}{
+		{scenario: "with Searched",
+			sut:    FileNotFoundError{Path: ".env", Searched: []string{"/a/b", "/a"}},
+			result: "env.FileNotFoundError: searched: /a/b, /a",
+		},
+		{scenario: "with empty Searched",
+			sut:    FileNotFoundError{Path: ".env"},
+			result: "env.FileNotFoundError",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result := tc.sut.Error()
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestFileNotFoundError_Is(t *testing.T) {
+	// ARRANGE
+	sut := FileNotFoundError{Path: ".env", Searched: []string{"/a/b", "/a"}}
+	testcases := []struct {
+		scenario string
+		target   error
+		result   bool
+	}{
+		{scenario: "target: fs.ErrNotExist", target: fs.ErrNotExist, result: true},
+		{scenario: "target: other error", target: errors.New("other"), result: false},
+		{scenario: "target: zero-value FileNotFoundError", target: FileNotFoundError{}, result: true},
+		{scenario: "target: FileNotFoundError with same fields", target: sut, result: true},
+		{scenario: "target: FileNotFoundError with different Path", target: FileNotFoundError{Path: "other.env"}, result: false},
+		{scenario: "target: FileNotFoundError with different Searched", target: FileNotFoundError{Searched: []string{"/a"}}, result: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result := errors.Is(sut, tc.target)
+
+			// ASSERT
+			test.Bool(t, result).Equals(tc.result)
+		})
+	}
+}
+
 func TestInvalidValueError_Error(t *testing.T) {
 	// ARRANGE
 	testcases := []struct {
@@ -241,6 +295,88 @@ func TestInvalidValueError_Unwrap(t *testing.T) {
 	test.Value(t, result).Equals(sut.Err)
 }
 
+func TestSyntaxError_Error(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		sut      SyntaxError
+		result   string
+	}{
+		{scenario: "all fields",
+			sut:    SyntaxError{File: "test.env", Line: 2, Column: 5, Text: "VAR", Err: ErrMissingEquals},
+			result: `env.SyntaxError: line 2, column 5: missing '=': "VAR"`,
+		},
+		{scenario: "no column",
+			sut:    SyntaxError{Line: 2, Text: "VAR", Err: ErrMissingEquals},
+			result: `env.SyntaxError: line 2: missing '=': "VAR"`,
+		},
+		{scenario: "no line",
+			sut:    SyntaxError{Column: 5, Text: "VAR", Err: ErrMissingEquals},
+			result: `env.SyntaxError: missing '=': "VAR"`,
+		},
+		{scenario: "no text",
+			sut:    SyntaxError{Line: 2, Column: 5, Err: ErrMissingEquals},
+			result: `env.SyntaxError: line 2, column 5: missing '='`,
+		},
+		{scenario: "no error",
+			sut:    SyntaxError{Line: 2, Column: 5, Text: "VAR"},
+			result: `env.SyntaxError: line 2, column 5: "VAR"`,
+		},
+		{scenario: "zero value",
+			result: "env.SyntaxError",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result := tc.sut.Error()
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestSyntaxError_Is(t *testing.T) {
+	// ARRANGE
+	sut := SyntaxError{File: "test.env", Line: 2, Column: 5, Text: "VAR", Err: ErrMissingEquals}
+	testcases := []struct {
+		scenario string
+		target   error
+		result   bool
+	}{
+		{scenario: "target: non-SyntaxError", target: ErrMissingEquals, result: false},
+		{scenario: "target: zero-value SyntaxError", target: SyntaxError{}, result: true},
+		{scenario: "target: SyntaxError with same fields", target: sut, result: true},
+		{scenario: "target: SyntaxError with same Err", target: SyntaxError{Err: ErrMissingEquals}, result: true},
+		{scenario: "target: SyntaxError with different File", target: SyntaxError{File: "other.env"}, result: false},
+		{scenario: "target: SyntaxError with different Line", target: SyntaxError{Line: 1}, result: false},
+		{scenario: "target: SyntaxError with different Column", target: SyntaxError{Column: 1}, result: false},
+		{scenario: "target: SyntaxError with different Text", target: SyntaxError{Text: "OTHER"}, result: false},
+		{scenario: "target: SyntaxError with different Err", target: SyntaxError{Err: ErrInvalidName}, result: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result := sut.Is(tc.target)
+
+			// ASSERT
+			test.Bool(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestSyntaxError_Unwrap(t *testing.T) {
+	// ARRANGE
+	sut := SyntaxError{Err: ErrUnterminatedQuote}
+
+	// ACT
+	result := sut.Unwrap()
+
+	// ASSERT
+	test.Value(t, result).Equals(ErrUnterminatedQuote)
+}
+
 func TestRangeError_Error(t *testing.T) {
 	// ARRANGE
 	testcases := []struct {
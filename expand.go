@@ -0,0 +1,307 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// expandFunc is a function that returns the value of a variable referenced
+// in a value being expanded.  The bool result indicates whether the variable
+// is set; the error result is any error resolving the value of the variable.
+type expandFunc = func(name string) (string, bool, error)
+
+// expand returns s with any variable references replaced by the values
+// obtained from a lookup function.  The following forms are supported:
+//
+//	$NAME              // the value of NAME; empty if NAME is not set
+//	${NAME}            // the value of NAME; empty if NAME is not set
+//	${NAME:-default}   // default if NAME is not set or is empty
+//	${NAME-default}    // default if NAME is not set
+//	${NAME:?message}   // an error if NAME is not set or is empty
+//	${NAME?message}    // an error if NAME is not set
+//	${NAME:+alt}       // alt if NAME is set and not empty, otherwise empty
+//	${NAME+alt}        // alt if NAME is set, otherwise empty
+//	$$                 // a literal '$'
+//
+// The default, message and alt words may themselves contain references,
+// which are expanded only if the word is used.
+//
+// A '$' that is not followed by '$', '{' or a valid name is retained as a
+// literal '$'.
+//
+// # returns
+//
+//	string   // the expanded value
+//
+//	error    // ErrInvalidReference if a ${...} reference is malformed;
+//	         // ErrNotSet (wrapped with the variable name) if a ${NAME:?}
+//	         // or ${NAME?} reference is not satisfied; or any error
+//	         // returned by the lookup function
+func expand(s string, lookup expandFunc) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	sb := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		switch c := s[i+1]; {
+		case c == '$':
+			sb.WriteByte('$')
+			i++
+
+		case c == '{':
+			end := closingBrace(s, i+1)
+			if end == -1 {
+				return "", fmt.Errorf("%w: %s", ErrInvalidReference, s[i:])
+			}
+			v, err := expandReference(s[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(v)
+			i = end
+
+		case isNameStart(c):
+			end := i + 2
+			for end < len(s) && isNameChar(s[end]) {
+				end++
+			}
+			v, _, err := lookup(s[i+1 : end])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(v)
+			i = end - 1
+
+		default:
+			sb.WriteByte('$')
+		}
+	}
+	return sb.String(), nil
+}
+
+// expandReference returns the value of the reference enclosed in a ${...}
+// expression, applying any operator in the expression.
+func expandReference(expr string, lookup expandFunc) (string, error) {
+	n := 0
+	for n < len(expr) && isNameChar(expr[n]) {
+		n++
+	}
+	if n == 0 || !isNameStart(expr[0]) {
+		return "", fmt.Errorf("%w: ${%s}", ErrInvalidReference, expr)
+	}
+	name, op := expr[:n], expr[n:]
+
+	value, isSet, err := lookup(name)
+	if err != nil {
+		return "", err
+	}
+	if op == "" {
+		return value, nil
+	}
+
+	// with a colon, an empty value is treated as not set
+	if op[0] == ':' {
+		op = op[1:]
+		isSet = isSet && value != ""
+	}
+	if op == "" {
+		return "", fmt.Errorf("%w: ${%s}", ErrInvalidReference, expr)
+	}
+
+	switch word := op[1:]; op[0] {
+	case '-':
+		if isSet {
+			return value, nil
+		}
+		return expand(word, lookup)
+	case '+':
+		if !isSet {
+			return "", nil
+		}
+		return expand(word, lookup)
+	case '?':
+		if isSet {
+			return value, nil
+		}
+		msg, err := expand(word, lookup)
+		if err != nil {
+			return "", err
+		}
+		if msg == "" {
+			return "", fmt.Errorf("%s: %w", name, ErrNotSet)
+		}
+		return "", fmt.Errorf("%s: %w: %s", name, ErrNotSet, msg)
+	default:
+		return "", fmt.Errorf("%w: ${%s}", ErrInvalidReference, expr)
+	}
+}
+
+// closingBrace returns the index of the brace that closes the brace at
+// s[open], or -1 if the brace is not closed.
+func closingBrace(s string, open int) int {
+	depth := 0
+	for i := open + 1; i < len(s); i++ {
+		switch s[i] {
+		case '$':
+			if i+1 < len(s) && s[i+1] == '$' {
+				i++
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// isNameStart returns true if c may be the first character of a name.
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// isNameChar returns true if c may appear in a name.
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
+
+// expandEntries expands the variable references in the values of entries read
+// from a .env file.
+//
+// A reference to a variable is resolved using the value of:
+//
+//  1. the variable as returned by the lookup function, if the variable is
+//     identified by the retained function (i.e. is set and will not be
+//     replaced by entries in the file, according to the override mode);
+//  2. the closest preceding entry for the variable in the same file;
+//  3. the variable as returned by the lookup function (i.e. set by an
+//     earlier file or in the process environment);
+//  4. the first following entry for the variable in the same file.
+//
+// A reference to a following entry that (directly or indirectly) refers back
+// to the referencing entry is a cycle, resulting in ErrCyclicReference.
+//
+// # parameters
+//
+//	entries []entry                      // the entries to expand
+//
+//	lookup func(string) (string, bool)   // a function returning variables that
+//	                                     // may be referenced by the entries
+//
+//	retained func(string) bool           // (optional) a function identifying
+//	                                     // variables that are not replaced by
+//	                                     // entries; may be nil
+//
+// # returns
+//
+//	[]entry   // entries for which the values were successfully expanded
+//
+//	error     // errors expanding any entries, identifying the line and name
+//	          // of the entry
+func expandEntries(entries []entry, lookup func(string) (string, bool), retained func(string) bool) ([]entry, error) {
+	r := &resolver{
+		entries:  entries,
+		lookup:   lookup,
+		retained: retained,
+		values:   make([]string, len(entries)),
+		errs:     make([]error, len(entries)),
+		state:    make([]resolveState, len(entries)),
+	}
+
+	result := make([]entry, 0, len(entries))
+	errs := []error{}
+	for i, e := range entries {
+		v, err := r.resolve(i)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %s: %w", e.line, e.name, err))
+			continue
+		}
+		e.value = v
+		result = append(result, e)
+	}
+	return result, errors.Join(errs...)
+}
+
+// resolveState is the state of an entry being resolved by a resolver.
+type resolveState int
+
+const (
+	unresolved resolveState = iota
+	resolving
+	resolved
+)
+
+// resolver expands the values of entries read from a .env file, resolving
+// the entries in the order they are referenced.
+type resolver struct {
+	entries  []entry
+	lookup   func(string) (string, bool)
+	retained func(string) bool
+	values   []string
+	errs     []error
+	state    []resolveState
+	stack    []int
+}
+
+// resolve returns the expanded value of the entry at index i.
+func (r *resolver) resolve(i int) (string, error) {
+	switch r.state[i] {
+	case resolved:
+		return r.values[i], r.errs[i]
+	case resolving:
+		cycle := []string{}
+		for n := len(r.stack) - 1; n >= 0; n-- {
+			cycle = append([]string{r.entries[r.stack[n]].name}, cycle...)
+			if r.stack[n] == i {
+				break
+			}
+		}
+		cycle = append(cycle, r.entries[i].name)
+		return "", fmt.Errorf("%w: %s", ErrCyclicReference, strings.Join(cycle, " -> "))
+	}
+
+	r.state[i] = resolving
+	r.stack = append(r.stack, i)
+	v, err := expand(r.entries[i].value, func(name string) (string, bool, error) {
+		return r.reference(i, name)
+	})
+	r.stack = r.stack[:len(r.stack)-1]
+	r.state[i] = resolved
+	r.values[i], r.errs[i] = v, err
+
+	return v, err
+}
+
+// reference returns the value of a variable referenced by the entry at index i.
+func (r *resolver) reference(i int, name string) (string, bool, error) {
+	if r.retained != nil && r.retained(name) {
+		v, ok := r.lookup(name)
+		return v, ok, nil
+	}
+	for j := i - 1; j >= 0; j-- {
+		if r.entries[j].name == name {
+			v, err := r.resolve(j)
+			return v, true, err
+		}
+	}
+	if v, ok := r.lookup(name); ok {
+		return v, true, nil
+	}
+	for j := i + 1; j < len(r.entries); j++ {
+		if r.entries[j].name == name {
+			v, err := r.resolve(j)
+			return v, true, err
+		}
+	}
+	return "", false, nil
+}
@@ -0,0 +1,204 @@
+package env
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blugnu/test"
+)
+
+func TestExpand(t *testing.T) {
+	// ARRANGE
+	vars := Vars{
+		"HOST":  "example.com",
+		"PORT":  "8080",
+		"EMPTY": "",
+	}
+	lookup := func(name string) (string, bool, error) {
+		v, ok := vars[name]
+		return v, ok, nil
+	}
+	testcases := []struct {
+		scenario string
+		input    string
+		result   string
+		err      error
+	}{
+		{scenario: "no references", input: "value", result: "value"},
+		{scenario: "simple reference", input: "$HOST", result: "example.com"},
+		{scenario: "simple reference/embedded", input: "http://$HOST:$PORT/path", result: "http://example.com:8080/path"},
+		{scenario: "simple reference/not set", input: "[$UNSET]", result: "[]"},
+		{scenario: "braced reference", input: "${HOST}", result: "example.com"},
+		{scenario: "braced reference/adjacent text", input: "${HOST}name", result: "example.comname"},
+		{scenario: "braced reference/not set", input: "[${UNSET}]", result: "[]"},
+		{scenario: "escaped dollar", input: "$$HOST", result: "$HOST"},
+		{scenario: "trailing dollar", input: "cost: 5$", result: "cost: 5$"},
+		{scenario: "dollar before non-name", input: "$1 and $-", result: "$1 and $-"},
+		{scenario: ":- with value", input: "${HOST:-default}", result: "example.com"},
+		{scenario: ":- with empty", input: "${EMPTY:-default}", result: "default"},
+		{scenario: ":- with not set", input: "${UNSET:-default}", result: "default"},
+		{scenario: "- with empty", input: "${EMPTY-default}", result: ""},
+		{scenario: "- with not set", input: "${UNSET-default}", result: "default"},
+		{scenario: ":+ with value", input: "${HOST:+alt}", result: "alt"},
+		{scenario: ":+ with empty", input: "${EMPTY:+alt}", result: ""},
+		{scenario: "+ with empty", input: "${EMPTY+alt}", result: "alt"},
+		{scenario: "+ with not set", input: "${UNSET+alt}", result: ""},
+		{scenario: ":? with value", input: "${HOST:?required}", result: "example.com"},
+		{scenario: ":? with empty", input: "${EMPTY:?required}", err: ErrNotSet},
+		{scenario: ":? with not set", input: "${UNSET:?}", err: ErrNotSet},
+		{scenario: "? with empty", input: "${EMPTY?required}", result: ""},
+		{scenario: "? with not set", input: "${UNSET?required}", err: ErrNotSet},
+		{scenario: "nested default", input: "${UNSET:-${HOST}:${PORT}}", result: "example.com:8080"},
+		{scenario: "nested default/not used", input: "${HOST:-${UNSET:?required}}", result: "example.com"},
+		{scenario: "default with braces", input: "${UNSET:-{}}", result: "{}"},
+		{scenario: "invalid/unclosed", input: "${HOST", err: ErrInvalidReference},
+		{scenario: "invalid/empty", input: "${}", err: ErrInvalidReference},
+		{scenario: "invalid/name", input: "${1HOST}", err: ErrInvalidReference},
+		{scenario: "invalid/operator", input: "${HOST/x}", err: ErrInvalidReference},
+		{scenario: "invalid/colon", input: "${HOST:}", err: ErrInvalidReference},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := expand(tc.input, lookup)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestExpand_RequiredMessage(t *testing.T) {
+	// ARRANGE
+	lookup := func(string) (string, bool, error) { return "", false, nil }
+
+	// ACT
+	_, err := expand("${DB_HOST:?database host is required}", lookup)
+
+	// ASSERT
+	test.Error(t, err).Is(ErrNotSet)
+	test.That(t, err.Error()).Equals("DB_HOST: not set: database host is required")
+}
+
+func TestExpand_WhenLookupFails(t *testing.T) {
+	// ARRANGE
+	lookuperr := errors.New("lookup error")
+	lookup := func(string) (string, bool, error) { return "", false, lookuperr }
+
+	// ACT
+	_, err := expand("${VAR:-default}", lookup)
+
+	// ASSERT
+	test.Error(t, err).Is(lookuperr)
+}
+
+func TestExpandEntries(t *testing.T) {
+	// ARRANGE
+	env := Vars{"HOST": "env.example.com", "SELF": "env-self"}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	testcases := []struct {
+		scenario string
+		entries  []entry
+		result   []entry
+		err      error
+		errmsg   string
+	}{
+		{scenario: "earlier entry",
+			entries: []entry{
+				{name: "HOST", value: "file.example.com", line: 1},
+				{name: "URL", value: "http://${HOST}", line: 2},
+			},
+			result: []entry{
+				{name: "HOST", value: "file.example.com", line: 1},
+				{name: "URL", value: "http://file.example.com", line: 2},
+			},
+		},
+		{scenario: "closest earlier entry",
+			entries: []entry{
+				{name: "HOST", value: "first", line: 1},
+				{name: "HOST", value: "second", line: 2},
+				{name: "URL", value: "$HOST", line: 3},
+				{name: "HOST", value: "third", line: 4},
+			},
+			result: []entry{
+				{name: "HOST", value: "first", line: 1},
+				{name: "HOST", value: "second", line: 2},
+				{name: "URL", value: "second", line: 3},
+				{name: "HOST", value: "third", line: 4},
+			},
+		},
+		{scenario: "environment in preference to later entry",
+			entries: []entry{
+				{name: "URL", value: "http://${HOST}", line: 1},
+				{name: "HOST", value: "file.example.com", line: 2},
+			},
+			result: []entry{
+				{name: "URL", value: "http://env.example.com", line: 1},
+				{name: "HOST", value: "file.example.com", line: 2},
+			},
+		},
+		{scenario: "later entry",
+			entries: []entry{
+				{name: "URL", value: "http://${NAME}.${DOMAIN}", line: 1},
+				{name: "NAME", value: "www", line: 2},
+				{name: "DOMAIN", value: "example.com", line: 3},
+			},
+			result: []entry{
+				{name: "URL", value: "http://www.example.com", line: 1},
+				{name: "NAME", value: "www", line: 2},
+				{name: "DOMAIN", value: "example.com", line: 3},
+			},
+		},
+		{scenario: "self reference",
+			entries: []entry{
+				{name: "SELF", value: "${SELF}:file", line: 1},
+				{name: "OTHER", value: "${OTHER}:file", line: 2},
+			},
+			result: []entry{
+				{name: "SELF", value: "env-self:file", line: 1},
+				{name: "OTHER", value: ":file", line: 2},
+			},
+		},
+		{scenario: "cycle",
+			entries: []entry{
+				{name: "A", value: "${B}", line: 1},
+				{name: "B", value: "${C}", line: 2},
+				{name: "C", value: "${A}", line: 3},
+				{name: "D", value: "value", line: 4},
+			},
+			result: []entry{
+				{name: "D", value: "value", line: 4},
+			},
+			err:    ErrCyclicReference,
+			errmsg: "line 1: A: cyclic reference: A -> B -> C -> A",
+		},
+		{scenario: "required reference not satisfied",
+			entries: []entry{
+				{name: "A", value: "${UNSET:?is required}", line: 1},
+				{name: "B", value: "value", line: 2},
+			},
+			result: []entry{
+				{name: "B", value: "value", line: 2},
+			},
+			err:    ErrNotSet,
+			errmsg: "line 1: A: UNSET: not set: is required",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result, err := expandEntries(tc.entries, lookup, nil)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			if tc.errmsg != "" {
+				test.String(t, err.Error()).Contains(tc.errmsg)
+			}
+			test.Slice(t, result).Equals(tc.result)
+		})
+	}
+}
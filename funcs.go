@@ -7,9 +7,11 @@ import (
 
 // function variables to facilitate testing
 var (
+	osGetwd     = os.Getwd
 	osLookupEnv = os.LookupEnv
 	osOpen      = os.Open
 	osSetenv    = os.Setenv
+	osStat      = os.Stat
 	osUnsetenv  = os.Unsetenv
 )
 
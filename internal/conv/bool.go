@@ -0,0 +1,48 @@
+// Package conv provides conversions shared by the env package (for Bind) and the
+// as package, so that both convert values in the same way.
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrNotABool is the error returned when a string is not an accepted bool value
+// (exported by the as package as as.ErrNotABool).
+var ErrNotABool = errors.New("not a boolean")
+
+var (
+	// Truthy and Falsy are the words accepted by Bool
+	Truthy = []string{"true", "t", "yes", "y", "on", "1", "enabled", "enable"}
+	Falsy  = []string{"false", "f", "no", "n", "off", "0", "disabled", "disable"}
+)
+
+// Bool converts a string to a bool using the Truthy and Falsy words, matched
+// regardless of case.
+func Bool(s string) (bool, error) {
+	return BoolFrom(Truthy, Falsy, true)(s)
+}
+
+// BoolFrom returns a function converting a string to a bool using specified
+// vocabularies.  The function returns an error wrapping ErrNotABool, identifying
+// the accepted values, if the string is not an accepted value.
+func BoolFrom(truthy, falsy []string, caseInsensitive bool) func(string) (bool, error) {
+	match := func(w string) func(string) bool {
+		if caseInsensitive {
+			return func(s string) bool { return strings.EqualFold(s, w) }
+		}
+		return func(s string) bool { return s == w }
+	}
+	return func(s string) (bool, error) {
+		switch {
+		case slices.ContainsFunc(truthy, match(s)):
+			return true, nil
+		case slices.ContainsFunc(falsy, match(s)):
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: accepted values are: %s (true), %s (false)",
+			ErrNotABool, strings.Join(truthy, ", "), strings.Join(falsy, ", "))
+	}
+}
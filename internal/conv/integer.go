@@ -0,0 +1,65 @@
+package conv
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Int converts a string to a signed integer of a given bit size.  The string
+// may have a base prefix and may include underscores between digits, as for Go
+// integer literals, except that a leading zero does not introduce an octal value.
+//
+// Errors are returned as for strconv.ParseInt, identifying the string converted.
+func Int(s string, bits int) (int64, error) {
+	i, err := strconv.ParseInt(decimal(s), 0, bits)
+	return i, numError(err, s)
+}
+
+// Uint converts a string to an unsigned integer of a given bit size, as for Int.
+//
+// Errors are returned as for strconv.ParseUint, identifying the string converted.
+func Uint(s string, bits int) (uint64, error) {
+	u, err := strconv.ParseUint(decimal(s), 0, bits)
+	return u, numError(err, s)
+}
+
+// decimal returns a string with any leading zeros that strconv would otherwise
+// interpret as introducing an octal value removed.  A leading zero that is followed
+// by a digit (or by an underscore and a digit) is removed; if the remainder has a
+// leading zero that is not removed (e.g. "00x1"), the result is an invalid integer
+// literal.
+func decimal(s string) string {
+	sign, t := "", s
+	if len(t) > 0 && (t[0] == '+' || t[0] == '-') {
+		sign, t = t[:1], t[1:]
+	}
+	if len(t) < 2 || t[0] != '0' || !(isDigit(t[1]) || t[1] == '_') {
+		return s
+	}
+	for len(t) > 1 && t[0] == '0' {
+		switch {
+		case isDigit(t[1]):
+			t = t[1:]
+		case t[1] == '_' && len(t) > 2 && isDigit(t[2]):
+			t = t[2:]
+		default:
+			return sign + "_" + t
+		}
+	}
+	return sign + t
+}
+
+// isDigit returns true if c is a decimal digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// numError returns an error with the Num field of any *strconv.NumError replaced
+// by the string that was converted.
+func numError(err error, s string) error {
+	var nerr *strconv.NumError
+	if errors.As(err, &nerr) {
+		nerr.Num = s
+	}
+	return err
+}
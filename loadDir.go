@@ -0,0 +1,135 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// LoadDir loads environment variables from the files in a directory, where the name
+// of each file is the name of a variable and the contents of the file is the value.
+// This is the layout used by Kubernetes when projecting ConfigMaps and Secrets as
+// volumes.
+//
+// Only regular files (or symbolic links to regular files) are loaded.  Files with
+// names beginning with a '.' are ignored; this includes the "..data" link and the
+// timestamped directories that Kubernetes uses to update volumes atomically.  Sub-
+// directories are not loaded.
+//
+// The contents of each file are used as the value without modification; values are
+// not expanded and any trailing newline is retained.
+//
+// # parameters
+//
+//	dir string           // the path of the directory
+//
+//	opts ...LoadOption   // options to apply (see LoadWithOptions)
+//
+// # returns
+//
+//	error      // an error that wraps all errors that occurred while loading variables;
+//	           // if no errors occurred the result is nil
+//
+// # options
+//
+// Variables are set according to the same override mode as Load (OverrideAlways,
+// unless specified using WithOverrideMode).  Files may be loaded from a file system
+// specified using WithFS.
+//
+// File names that are not valid variable names may be transformed using a function
+// specified using WithNameTransform, e.g. UpperSnakeCase:
+//
+//	// loads a file named "db-host" as the variable DB_HOST
+//	err := env.LoadDir("/etc/config", env.WithNameTransform(env.UpperSnakeCase))
+//
+// A file with a name that is not a valid variable name (after any transformation)
+// results in an error wrapping ErrInvalidName; any other files are loaded.
+func LoadDir(dir string, opts ...LoadOption) error {
+	l := newLoader(opts...)
+	vars, err := l.readDir(dir)
+	return errors.Join(err, l.apply(vars))
+}
+
+// readDir reads variables from the regular files in a directory, as described
+// for LoadDir.
+func (l *loader) readDir(dir string) (Vars, error) {
+	fsys, root := l.fsys, path.Clean(dir)
+	filePath := func(name string) string { return path.Join(root, name) }
+	if fsys == nil {
+		fsys, root = os.DirFS(dir), "."
+		filePath = func(name string) string { return filepath.Join(dir, name) }
+	}
+
+	entries, err := fs.ReadDir(fsys, root)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+
+	vars := Vars{}
+	errs := []error{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		// fs.Stat follows symbolic links, identifying links to regular files
+		p := path.Join(root, entry.Name())
+		info, err := fs.Stat(fsys, p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filePath(entry.Name()), err))
+			continue
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		name := entry.Name()
+		if l.nameTransform != nil {
+			name = l.nameTransform(name)
+		}
+		if name == "" || invalidNameChar(name) != -1 {
+			errs = append(errs, fmt.Errorf("%s: %w: %q", filePath(entry.Name()), ErrInvalidName, name))
+			continue
+		}
+
+		value, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filePath(entry.Name()), err))
+			continue
+		}
+		vars[name] = string(value)
+	}
+	return vars, errors.Join(errs...)
+}
+
+// UpperSnakeCase returns a name converted to upper snake case, for use with
+// WithNameTransform.  Letters are converted to upper case, any other character
+// that is not a letter or digit is replaced by an underscore and an underscore
+// is inserted between a lower case letter or digit and a following upper case
+// letter:
+//
+//	UpperSnakeCase("db-host")      // DB_HOST
+//	UpperSnakeCase("db.password")  // DB_PASSWORD
+//	UpperSnakeCase("logLevel")     // LOG_LEVEL
+func UpperSnakeCase(name string) string {
+	sb := strings.Builder{}
+	prev := rune(0)
+	for _, r := range name {
+		switch {
+		case unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			sb.WriteByte('_')
+			sb.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(unicode.ToUpper(r))
+		default:
+			sb.WriteByte('_')
+		}
+		prev = r
+	}
+	return sb.String()
+}
@@ -0,0 +1,132 @@
+package env
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/blugnu/test"
+)
+
+func TestLoadDir(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		fsys     fstest.MapFS
+		opts     []LoadOption
+		result   Vars
+		err      error
+	}{
+		{scenario: "files",
+			fsys: fstest.MapFS{
+				"config/DB_HOST":     {Data: []byte("db.example.com")},
+				"config/DB_PASSWORD": {Data: []byte("secret\n")},
+			},
+			result: Vars{"EXISTING": "env", "DB_HOST": "db.example.com", "DB_PASSWORD": "secret\n"},
+		},
+		{scenario: "dot-files and directories ignored",
+			fsys: fstest.MapFS{
+				"config/DB_HOST":          {Data: []byte("db.example.com")},
+				"config/.hidden":          {Data: []byte("hidden")},
+				"config/..data/DB_HOST":   {Data: []byte("db.example.com")},
+				"config/nested/DB_SCHEMA": {Data: []byte("schema")},
+			},
+			result: Vars{"EXISTING": "env", "DB_HOST": "db.example.com"},
+		},
+		{scenario: "invalid names",
+			fsys: fstest.MapFS{
+				"config/db-host": {Data: []byte("db.example.com")},
+				"config/DB_PORT": {Data: []byte("5432")},
+			},
+			result: Vars{"EXISTING": "env", "DB_PORT": "5432"},
+			err:    ErrInvalidName,
+		},
+		{scenario: "name transform",
+			fsys: fstest.MapFS{
+				"config/db-host": {Data: []byte("db.example.com")},
+			},
+			opts:   []LoadOption{WithNameTransform(UpperSnakeCase)},
+			result: Vars{"EXISTING": "env", "DB_HOST": "db.example.com"},
+		},
+		{scenario: "override mode",
+			fsys: fstest.MapFS{
+				"config/EXISTING": {Data: []byte("file")},
+				"config/DB_HOST":  {Data: []byte("db.example.com")},
+			},
+			opts:   []LoadOption{WithOverrideMode(OverrideNever)},
+			result: Vars{"EXISTING": "env", "DB_HOST": "db.example.com"},
+		},
+		{scenario: "override mode/default",
+			fsys: fstest.MapFS{
+				"config/EXISTING": {Data: []byte("file")},
+			},
+			result: Vars{"EXISTING": "file"},
+		},
+		{scenario: "directory does not exist",
+			fsys:   fstest.MapFS{},
+			result: Vars{"EXISTING": "env"},
+			err:    fs.ErrNotExist,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			defer State().Reset()
+			os.Clearenv()
+			os.Setenv("EXISTING", "env")
+
+			// ACT
+			err := LoadDir("config", append(tc.opts, WithFS(tc.fsys))...)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.Map(t, GetVars()).Equals(tc.result)
+		})
+	}
+}
+
+func TestLoadDir_KubernetesVolume(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+
+	// a volume projected by Kubernetes consists of links to files in a
+	// timestamped directory, identified by the ..data link
+	dir := t.TempDir()
+	ts := filepath.Join(dir, "..2024_01_01_00_00_00.000000000")
+	test.That(t, os.Mkdir(ts, 0o755)).IsNil()
+	test.That(t, os.WriteFile(filepath.Join(ts, "db-host"), []byte("db.example.com"), 0o644)).IsNil()
+	test.That(t, os.Symlink(filepath.Base(ts), filepath.Join(dir, "..data"))).IsNil()
+	test.That(t, os.Symlink(filepath.Join("..data", "db-host"), filepath.Join(dir, "db-host"))).IsNil()
+
+	// ACT
+	err := LoadDir(dir, WithNameTransform(UpperSnakeCase))
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.Map(t, GetVars()).Equals(Vars{"DB_HOST": "db.example.com"})
+}
+
+func TestUpperSnakeCase(t *testing.T) {
+	testcases := []struct {
+		name   string
+		result string
+	}{
+		{name: "DB_HOST", result: "DB_HOST"},
+		{name: "db-host", result: "DB_HOST"},
+		{name: "db.password", result: "DB_PASSWORD"},
+		{name: "logLevel", result: "LOG_LEVEL"},
+		{name: "http2Enabled", result: "HTTP2_ENABLED"},
+		{name: "HTTPProxy", result: "HTTPPROXY"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			result := UpperSnakeCase(tc.name)
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package env
+
+import "io/fs"
+
+// LoadFS loads environment variables from one or more files in a file system (fs.FS),
+// such as an embed.FS or fstest.MapFS.  Files are loaded according to the same rules
+// as for Load, including the rules for loading ".env" (from the root of the file
+// system).
+//
+// # parameters
+//
+//	fsys fs.FS          // the file system from which to load files
+//
+//	files: ...string    // 0..n file path(s), relative to the root of fsys
+//
+// # returns
+//
+//	error      // an error that wraps all errors that occurred while loading variables;
+//	           // if no errors occurred the result is nil
+//
+// To load files from a file system with other options, use LoadWithOptions with
+// the WithFS option.
+//
+// # example: loading default configuration embedded in a binary
+//
+//	//go:embed defaults.env
+//	var defaults embed.FS
+//
+//	func init() {
+//		if err := env.LoadFS(defaults, "defaults.env"); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func LoadFS(fsys fs.FS, files ...string) error {
+	return newLoader(WithFS(fsys)).load(files)
+}
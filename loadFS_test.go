@@ -0,0 +1,97 @@
+package env
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/blugnu/test"
+)
+
+func TestLoadFS(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		fsys     fstest.MapFS
+		files    []string
+		result   Vars
+		err      error
+	}{
+		{scenario: "no files/.env exists",
+			fsys: fstest.MapFS{
+				".env": {Data: []byte("VAR1=dotenv-1\nVAR2=dotenv-2")},
+			},
+			result: Vars{"EXISTING": "env", "VAR1": "dotenv-1", "VAR2": "dotenv-2"},
+		},
+		{scenario: "no files/.env does not exist",
+			fsys:   fstest.MapFS{},
+			result: Vars{"EXISTING": "env"},
+			err:    fs.ErrNotExist,
+		},
+		{scenario: "files/.env does not exist",
+			fsys: fstest.MapFS{
+				"config/test.env": {Data: []byte("VAR=test")},
+			},
+			files:  []string{"config/test.env"},
+			result: Vars{"EXISTING": "env", "VAR": "test"},
+		},
+		{scenario: "files/.env exists",
+			fsys: fstest.MapFS{
+				".env":            {Data: []byte("VAR=dotenv\nEXISTING=dotenv")},
+				"config/test.env": {Data: []byte("VAR=test")},
+			},
+			files:  []string{"config/test.env"},
+			result: Vars{"EXISTING": "dotenv", "VAR": "test"},
+		},
+		{scenario: "explicit ./.env",
+			fsys: fstest.MapFS{
+				".env":     {Data: []byte("VAR=dotenv")},
+				"test.env": {Data: []byte("VAR=test")},
+			},
+			files:  []string{"test.env", "./.env"},
+			result: Vars{"EXISTING": "env", "VAR": "dotenv"},
+		},
+		{scenario: "file does not exist",
+			fsys:   fstest.MapFS{},
+			files:  []string{"test.env"},
+			result: Vars{"EXISTING": "env"},
+			err:    fs.ErrNotExist,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ARRANGE
+			defer State().Reset()
+			defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+				panic("unexpected use of OS file system: " + path)
+			})()
+			os.Clearenv()
+			os.Setenv("EXISTING", "env")
+
+			// ACT
+			err := LoadFS(tc.fsys, tc.files...)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.Map(t, GetVars()).Equals(tc.result)
+		})
+	}
+}
+
+func TestLoadWithOptions_WithFS(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("EXISTING", "env")
+	fsys := fstest.MapFS{
+		".env": {Data: []byte("EXISTING=dotenv\nVAR=dotenv")},
+	}
+
+	// ACT
+	err := LoadWithOptions(nil, WithFS(fsys), WithOverrideMode(OverrideNever))
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.Map(t, GetVars()).Equals(Vars{"EXISTING": "env", "VAR": "dotenv"})
+}
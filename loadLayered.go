@@ -0,0 +1,70 @@
+package env
+
+// ModeVariable is the name of the environment variable that identifies the mode
+// used by LoadLayered when no mode is specified.
+const ModeVariable = "APP_ENV"
+
+// LoadLayered loads environment variables from the conventional layers of .env
+// files for a specified mode (e.g. "development", "production" or "test").
+//
+// The layers are loaded in order of increasing precedence:
+//
+//	.env                 // defaults for all modes
+//	.env.local           // local overrides for all modes (except "test")
+//	.env.<mode>          // mode-specific settings
+//	.env.<mode>.local    // local mode-specific overrides (except "test")
+//
+// The ".local" layers are not loaded when the mode is "test", so that tests
+// produce the same results for everyone.
+//
+// All layers are optional; a layer that does not exist is ignored without error.
+//
+// # parameters
+//
+//	mode string          // the mode; if empty, the mode is obtained from the
+//	                     // APP_ENV environment variable (ModeVariable).  If no
+//	                     // mode is specified or set, only the ".env" and
+//	                     // ".env.local" layers are loaded
+//
+//	opts ...LoadOption   // options to apply (see LoadWithOptions)
+//
+// # returns
+//
+//	error      // an error that wraps all errors that occurred while loading variables;
+//	           // if no errors occurred the result is nil
+//
+// # override mode
+//
+// Unless otherwise specified (using WithOverrideMode), variables are loaded using
+// OverrideLoaded: a variable already set in the environment is not replaced, with
+// variables in each layer replacing those loaded from lower precedence layers.
+//
+// OverrideAlways may be specified to allow the layers to replace variables set in
+// the environment.  OverrideNever should not be used, since the first layer to set
+// a variable would then take precedence over the higher precedence layers.
+//
+// # example
+//
+//	if err := env.LoadLayered("development"); err != nil {
+//		log.Fatal(err)
+//	}
+func LoadLayered(mode string, opts ...LoadOption) error {
+	if mode == "" {
+		mode, _ = osLookupEnv(ModeVariable)
+	}
+	l := newLoader(append([]LoadOption{WithOverrideMode(OverrideLoaded)}, opts...)...)
+	return l.forEachOptionalFile(layers(mode), func(string) bool { return true }, l.loadFile)
+}
+
+// layers returns the files to be loaded for a mode, in order of increasing
+// precedence.
+func layers(mode string) []string {
+	if mode == "test" {
+		return []string{".env", ".env.test"}
+	}
+	files := []string{".env", ".env.local"}
+	if mode != "" {
+		files = append(files, ".env."+mode, ".env."+mode+".local")
+	}
+	return files
+}
@@ -0,0 +1,155 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/blugnu/test"
+)
+
+func TestLoadLayered(t *testing.T) {
+	// ARRANGE
+	fsys := fstest.MapFS{
+		".env":                   {Data: []byte("LAYER=.env\nDOTENV=.env\nEXISTING=.env")},
+		".env.local":             {Data: []byte("LAYER=.env.local\nLOCAL=.env.local\nMODE=.env.local")},
+		".env.development":       {Data: []byte("LAYER=.env.development\nMODE=.env.development")},
+		".env.development.local": {Data: []byte("LAYER=.env.development.local")},
+		".env.test":              {Data: []byte("LAYER=.env.test\nMODE=.env.test")},
+		".env.test.local":        {Data: []byte("LAYER=.env.test.local")},
+		".env.production.local":  {Data: []byte("LAYER=.env.production.local")},
+	}
+	testcases := []struct {
+		scenario string
+		mode     string
+		appEnv   string
+		opts     []LoadOption
+		result   Vars
+	}{
+		{scenario: "development",
+			mode: "development",
+			result: Vars{
+				"EXISTING": "env",
+				"LAYER":    ".env.development.local",
+				"DOTENV":   ".env",
+				"LOCAL":    ".env.local",
+				"MODE":     ".env.development",
+			},
+		},
+		{scenario: "test (no .local layers)",
+			mode: "test",
+			result: Vars{
+				"EXISTING": "env",
+				"LAYER":    ".env.test",
+				"DOTENV":   ".env",
+				"MODE":     ".env.test",
+			},
+		},
+		{scenario: "production (missing layers)",
+			mode: "production",
+			result: Vars{
+				"EXISTING": "env",
+				"LAYER":    ".env.production.local",
+				"DOTENV":   ".env",
+				"LOCAL":    ".env.local",
+				"MODE":     ".env.local",
+			},
+		},
+		{scenario: "no layers exist",
+			mode: "staging",
+			result: Vars{
+				"EXISTING": "env",
+				"LAYER":    ".env.local",
+				"DOTENV":   ".env",
+				"LOCAL":    ".env.local",
+				"MODE":     ".env.local",
+			},
+		},
+		{scenario: "mode from APP_ENV",
+			appEnv: "test",
+			result: Vars{
+				"APP_ENV":  "test",
+				"EXISTING": "env",
+				"LAYER":    ".env.test",
+				"DOTENV":   ".env",
+				"MODE":     ".env.test",
+			},
+		},
+		{scenario: "no mode",
+			result: Vars{
+				"EXISTING": "env",
+				"LAYER":    ".env.local",
+				"DOTENV":   ".env",
+				"LOCAL":    ".env.local",
+				"MODE":     ".env.local",
+			},
+		},
+		{scenario: "OverrideAlways",
+			mode: "test",
+			opts: []LoadOption{WithOverrideMode(OverrideAlways)},
+			result: Vars{
+				"EXISTING": ".env",
+				"LAYER":    ".env.test",
+				"DOTENV":   ".env",
+				"MODE":     ".env.test",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ARRANGE
+			defer State().Reset()
+			os.Clearenv()
+			os.Setenv("EXISTING", "env")
+			if tc.appEnv != "" {
+				os.Setenv("APP_ENV", tc.appEnv)
+			}
+
+			// ACT
+			err := LoadLayered(tc.mode, append([]LoadOption{WithFS(fsys)}, tc.opts...)...)
+
+			// ASSERT
+			test.That(t, err).IsNil()
+			test.Map(t, GetVars()).Equals(tc.result)
+		})
+	}
+}
+
+func TestLoadLayered_WhenLayerIsInvalid(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	fsys := fstest.MapFS{
+		".env":                   {Data: []byte("VAR=.env")},
+		".env.development":       {Data: []byte("INVALID")},
+		".env.development.local": {Data: []byte("VAR=.env.development.local")},
+	}
+
+	// ACT
+	err := LoadLayered("development", WithFS(fsys))
+
+	// ASSERT
+	test.Error(t, err).Is(SyntaxError{File: ".env.development", Err: ErrMissingEquals})
+	test.String(t, err.Error()).Contains(".env.development: env.SyntaxError")
+	test.That(t, os.Getenv("VAR")).Equals(".env.development.local")
+}
+
+func TestLoadLayered_WhenLayerCannotBeRead(t *testing.T) {
+	// ARRANGE
+	openerr := errors.New("open error")
+	defer State().Reset()
+	defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+		if path == ".env.local" {
+			return nil, openerr
+		}
+		return nil, os.ErrNotExist
+	})()
+	os.Clearenv()
+
+	// ACT
+	err := LoadLayered("development")
+
+	// ASSERT
+	test.Error(t, err).Is(openerr)
+}
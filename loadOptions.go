@@ -0,0 +1,111 @@
+package env
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// LoadOption is a function that configures the way in which environment variables are
+// loaded from files by LoadWithOptions.
+type LoadOption func(*loader)
+
+// OverrideMode determines whether a variable loaded from a file replaces the value of
+// a variable that is already set.
+type OverrideMode int
+
+const (
+	// OverrideAlways replaces the value of any variable that is already set.  This is
+	// the default mode and the mode used by Load.
+	OverrideAlways OverrideMode = iota
+
+	// OverrideNever does not replace the value of any variable that is already set,
+	// including variables set by an earlier file in the same call.  The first file
+	// to set a variable (if not already set in the environment) takes precedence.
+	OverrideNever
+
+	// OverrideLoaded does not replace the value of a variable that was set before the
+	// call but does replace a variable set by an earlier file in the same call.  Later
+	// files take precedence over earlier files; the environment takes precedence over
+	// all files.
+	OverrideLoaded
+)
+
+// String returns the name of the mode.
+func (m OverrideMode) String() string {
+	switch m {
+	case OverrideAlways:
+		return "OverrideAlways"
+	case OverrideNever:
+		return "OverrideNever"
+	case OverrideLoaded:
+		return "OverrideLoaded"
+	default:
+		return fmt.Sprintf("OverrideMode(%d)", int(m))
+	}
+}
+
+// WithOverrideMode returns a LoadOption that sets the mode determining whether a
+// variable loaded from a file replaces a variable that is already set.
+//
+// # parameters
+//
+//	mode OverrideMode   // OverrideAlways, OverrideNever or OverrideLoaded
+func WithOverrideMode(mode OverrideMode) LoadOption {
+	return func(l *loader) {
+		l.mode = mode
+	}
+}
+
+// WithFS returns a LoadOption that loads files from a file system (fs.FS) instead
+// of the OS file system.  File paths are cleaned before being opened, so that
+// "./.env" (for example) identifies ".env" in the file system.
+//
+// # parameters
+//
+//	fsys fs.FS   // the file system from which to load files
+func WithFS(fsys fs.FS) LoadOption {
+	return func(l *loader) {
+		l.fsys = fsys
+	}
+}
+
+// WithSearchParents returns a LoadOption that locates files with relative paths by
+// searching the working directory and then each of its parent directories in turn,
+// loading the first file found.  This is useful when loading a .env file in the root
+// of a repository from tests, which run with the directory of each package as the
+// working directory.
+//
+// The search stops after searching the root directory or, if specified, a directory
+// containing any of the stopAt files (e.g. "go.mod" or ".git").  If a file is not
+// found, the error is a FileNotFoundError identifying the directories searched.
+//
+// Absolute file paths are not searched for. The option has no effect when loading
+// files from a file system specified using WithFS.
+//
+// # parameters
+//
+//	stopAt ...string   // (optional) names of files or directories that identify
+//	                   // the last directory to be searched
+//
+// # example
+//
+//	err := env.LoadWithOptions(nil, env.WithSearchParents("go.mod", ".git"))
+func WithSearchParents(stopAt ...string) LoadOption {
+	return func(l *loader) {
+		l.searchParents = true
+		l.stopAt = stopAt
+	}
+}
+
+// WithNameTransform returns a LoadOption that specifies a function to transform the
+// names of files loaded by LoadDir to the names of variables, e.g. UpperSnakeCase.
+// The option has no effect on files loaded by Load or LoadWithOptions.
+//
+// # parameters
+//
+//	fn func(string) string   // a function returning the variable name for a file name
+func WithNameTransform(fn func(string) string) LoadOption {
+	return func(l *loader) {
+		l.nameTransform = fn
+	}
+}
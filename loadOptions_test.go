@@ -0,0 +1,29 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/blugnu/test"
+)
+
+func TestOverrideMode_String(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		mode   OverrideMode
+		result string
+	}{
+		{mode: OverrideAlways, result: "OverrideAlways"},
+		{mode: OverrideNever, result: "OverrideNever"},
+		{mode: OverrideLoaded, result: "OverrideLoaded"},
+		{mode: OverrideMode(99), result: "OverrideMode(99)"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.result, func(t *testing.T) {
+			// ACT
+			result := tc.mode.String()
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/blugnu/test"
@@ -265,10 +266,383 @@ func TestLoadFile_WithEmptyLinesAndComments(t *testing.T) {
 	})()
 
 	// ACT
-	err := loadFile("test.env")
+	err := newLoader().loadFile("test.env")
 
 	// ASSERT
 	test.That(t, err).IsNil()
 	test.That(t, os.Getenv("VAR1")).Equals("value-1")
 	test.That(t, os.Getenv("VAR2")).Equals("value-2=with-equals")
 }
+
+func TestLoadFile_WithQuotedValues(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	defer test.Using(&newFileReader, func(string) (fileReader, error) {
+		return fakeFile("VAR1=\"hello world\"\nVAR2='single \\n quoted'\nVAR3=\"line1\\nline2\"\nVAR4=unquoted # comment"), nil
+	})()
+
+	// ACT
+	err := newLoader().loadFile("test.env")
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, os.Getenv("VAR1")).Equals("hello world")
+	test.That(t, os.Getenv("VAR2")).Equals(`single \n quoted`)
+	test.That(t, os.Getenv("VAR3")).Equals("line1\nline2")
+	test.That(t, os.Getenv("VAR4")).Equals("unquoted")
+}
+
+func TestLoadFile_WithInvalidQuotedValue(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	defer test.Using(&newFileReader, func(string) (fileReader, error) {
+		return fakeFile("VAR1=value\nVAR2=\"unterminated\nVAR3=value"), nil
+	})()
+
+	// ACT
+	err := newLoader().loadFile("test.env")
+
+	// ASSERT
+	test.Error(t, err).Is(ErrUnterminatedQuote)
+	test.That(t, err.Error()).Equals(`env.SyntaxError: line 2, column 6: unterminated quoted value: "VAR2=\"unterminated"`)
+	test.That(t, os.Getenv("VAR1")).Equals("value")
+	_, isSet := os.LookupEnv("VAR2")
+	test.IsFalse(t, isSet)
+	_, isSet = os.LookupEnv("VAR3")
+	test.IsFalse(t, isSet)
+}
+
+func TestLoad_WithMultiLineValue(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+		switch path {
+		case ".env":
+			return nil, fs.ErrNotExist
+		default:
+			return fakeFile("KEY=\"-----BEGIN KEY-----\nABC\n-----END KEY-----\"\nVAR=value"), nil
+		}
+	})()
+	os.Clearenv()
+
+	// ACT
+	err := Load("test.env")
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, os.Getenv("KEY")).Equals("-----BEGIN KEY-----\nABC\n-----END KEY-----")
+	test.That(t, os.Getenv("VAR")).Equals("value")
+}
+
+func TestLoad_WithUnterminatedMultiLineValue(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+		switch path {
+		case ".env":
+			return nil, fs.ErrNotExist
+		default:
+			return fakeFile("VAR=value\n\nKEY='-----BEGIN KEY-----\nABC\n"), nil
+		}
+	})()
+	os.Clearenv()
+
+	// ACT
+	err := Load("test.env")
+
+	// ASSERT
+	test.Error(t, err).Is(ErrUnterminatedQuote)
+	test.Error(t, err).Is(SyntaxError{File: "test.env", Line: 3, Column: 5})
+	test.That(t, os.Getenv("VAR")).Equals("value")
+}
+
+func TestLoad_WithVariableExpansion(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+		switch path {
+		case ".env":
+			return fakeFile("DB_HOST=db.example.com\nDB_USER=${USER}"), nil
+		case "test.env":
+			return fakeFile("DB_URL=\"postgres://${DB_USER}@${DB_HOST}:${DB_PORT:-5432}/app\"\nLITERAL='${DB_HOST}'"), nil
+		default:
+			panic("unexpected file path: " + path)
+		}
+	})()
+	os.Clearenv()
+	os.Setenv("USER", "admin")
+
+	// ACT
+	err := Load("test.env")
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, os.Getenv("DB_URL")).Equals("postgres://admin@db.example.com:5432/app")
+	test.That(t, os.Getenv("LITERAL")).Equals("${DB_HOST}")
+}
+
+func TestLoad_WithVariableExpansionErrors(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+		switch path {
+		case ".env":
+			return nil, fs.ErrNotExist
+		case "test.env":
+			return fakeFile("A=${B}\nB=${A}\nC=${DB_HOST:?must be set}\nD=value"), nil
+		default:
+			panic("unexpected file path: " + path)
+		}
+	})()
+	os.Clearenv()
+
+	// ACT
+	err := Load("test.env")
+
+	// ASSERT
+	test.Error(t, err).Is(ErrCyclicReference)
+	test.Error(t, err).Is(ErrNotSet)
+	test.String(t, err.Error()).Contains("line 3: C: DB_HOST: not set: must be set")
+	test.That(t, os.Getenv("D")).Equals("value")
+}
+
+func TestLoad_WithSyntaxErrors(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+		switch path {
+		case ".env":
+			return nil, fs.ErrNotExist
+		case "test.env":
+			return fakeFile("VAR1=value-1\nNOT A VARIABLE=value\nVAR2=value-2"), nil
+		default:
+			panic("unexpected file path: " + path)
+		}
+	})()
+	os.Clearenv()
+
+	// ACT
+	err := Load("test.env")
+
+	// ASSERT
+	test.Error(t, err).Is(SyntaxError{File: "test.env", Line: 2, Column: 4, Err: ErrInvalidName})
+	test.That(t, err.Error()).Equals(`test.env: env.SyntaxError: line 2, column 4: invalid variable name: "NOT A VARIABLE=value"`)
+	test.That(t, os.Getenv("VAR1")).Equals("value-1")
+	test.That(t, os.Getenv("VAR2")).Equals("value-2")
+}
+
+func TestLoadWithOptions_OverrideMode(t *testing.T) {
+	// ARRANGE
+	files := map[string]string{
+		".env":        "EXISTING=dotenv\nDOTENV=dotenv\nBOTH=dotenv",
+		"test.env":    "EXISTING=test\nTEST=test\nBOTH=test",
+		"missing.env": "",
+	}
+	testcases := []struct {
+		scenario string
+		opts     []LoadOption
+		result   Vars
+	}{
+		{scenario: "default",
+			result: Vars{"EXISTING": "test", "DOTENV": "dotenv", "TEST": "test", "BOTH": "test"},
+		},
+		{scenario: "OverrideAlways",
+			opts:   []LoadOption{WithOverrideMode(OverrideAlways)},
+			result: Vars{"EXISTING": "test", "DOTENV": "dotenv", "TEST": "test", "BOTH": "test"},
+		},
+		{scenario: "OverrideNever",
+			opts:   []LoadOption{WithOverrideMode(OverrideNever)},
+			result: Vars{"EXISTING": "env", "DOTENV": "dotenv", "TEST": "test", "BOTH": "dotenv"},
+		},
+		{scenario: "OverrideLoaded",
+			opts:   []LoadOption{WithOverrideMode(OverrideLoaded)},
+			result: Vars{"EXISTING": "env", "DOTENV": "dotenv", "TEST": "test", "BOTH": "test"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ARRANGE
+			defer State().Reset()
+			defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+				return fakeFile(files[path]), nil
+			})()
+			os.Clearenv()
+			os.Setenv("EXISTING", "env")
+
+			// ACT
+			err := LoadWithOptions([]string{"test.env"}, tc.opts...)
+
+			// ASSERT
+			test.That(t, err).IsNil()
+			test.Map(t, GetVars()).Equals(tc.result)
+		})
+	}
+}
+
+func TestLoadWithOptions_OverrideMode_References(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		mode     OverrideMode
+		result   string
+	}{
+		{scenario: "OverrideAlways", mode: OverrideAlways, result: "http://file:80"},
+		{scenario: "OverrideNever", mode: OverrideNever, result: "https://env:80"},
+		{scenario: "OverrideLoaded", mode: OverrideLoaded, result: "http://env:80"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ARRANGE
+			defer State().Reset()
+			defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+				if path == ".env" {
+					return fakeFile("SCHEME=https"), nil
+				}
+				return fakeFile("HOST=file\nSCHEME=http\nURL=${SCHEME}://${HOST}:${PORT}"), nil
+			})()
+			os.Clearenv()
+			os.Setenv("HOST", "env")
+			os.Setenv("PORT", "80")
+
+			// ACT
+			err := LoadWithOptions([]string{"test.env"}, WithOverrideMode(tc.mode))
+
+			// ASSERT
+			test.That(t, err).IsNil()
+			test.That(t, os.Getenv("URL")).Equals(tc.result)
+		})
+	}
+}
+
+func TestLoadWithOptions_OverrideMode_DuplicatesInFile(t *testing.T) {
+	// ARRANGE
+	for _, mode := range []OverrideMode{OverrideAlways, OverrideNever, OverrideLoaded} {
+		t.Run(mode.String(), func(t *testing.T) {
+			defer State().Reset()
+			defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+				return fakeFile("VAR=first\nVAR=last"), nil
+			})()
+			os.Clearenv()
+
+			// ACT
+			err := LoadWithOptions([]string{".env"}, WithOverrideMode(mode))
+
+			// ASSERT
+			test.That(t, err).IsNil()
+			test.That(t, os.Getenv("VAR")).Equals("last")
+		})
+	}
+}
+
+func TestLoadWithOptions_WhenSetFails(t *testing.T) {
+	// ARRANGE
+	seterr := errors.New("set error")
+	defer State().Reset()
+	defer test.Using(&osSetenv, func(string, string) error { return seterr })()
+	defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+		return fakeFile("VAR=value"), nil
+	})()
+	os.Clearenv()
+
+	// ACT
+	err := LoadWithOptions(nil)
+
+	// ASSERT
+	test.Error(t, err).Is(seterr)
+}
+
+func TestLoadWithOptions_WithSearchParents(t *testing.T) {
+	// ARRANGE
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	pkg := filepath.Join(repo, "internal", "pkg")
+	test.That(t, os.MkdirAll(pkg, 0o755)).IsNil()
+	test.That(t, os.WriteFile(filepath.Join(root, "outside.env"), []byte("VAR=outside"), 0o644)).IsNil()
+	test.That(t, os.WriteFile(filepath.Join(repo, "go.mod"), []byte("module repo"), 0o644)).IsNil()
+	test.That(t, os.WriteFile(filepath.Join(repo, ".env"), []byte("VAR=repo"), 0o644)).IsNil()
+	test.That(t, os.WriteFile(filepath.Join(pkg, "test.env"), []byte("PKG=pkg"), 0o644)).IsNil()
+
+	testcases := []struct {
+		scenario string
+		files    []string
+		stopAt   []string
+		result   Vars
+		err      error
+	}{
+		{scenario: ".env in parent",
+			result: Vars{"VAR": "repo"},
+		},
+		{scenario: ".env in parent/file in working directory",
+			files:  []string{"test.env"},
+			result: Vars{"VAR": "repo", "PKG": "pkg"},
+		},
+		{scenario: "file beyond stop directory",
+			files:  []string{"outside.env"},
+			stopAt: []string{"go.mod"},
+			result: Vars{"VAR": "repo"},
+			err: FileNotFoundError{
+				Path:     "outside.env",
+				Searched: []string{pkg, filepath.Join(repo, "internal"), repo},
+			},
+		},
+		{scenario: "file with no stop directory",
+			files:  []string{"outside.env"},
+			stopAt: []string{".git"},
+			result: Vars{"VAR": "outside"},
+		},
+		{scenario: "absolute path",
+			files:  []string{filepath.Join(root, "outside.env")},
+			stopAt: []string{"go.mod"},
+			result: Vars{"VAR": "outside"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ARRANGE
+			defer State().Reset()
+			defer test.Using(&osGetwd, func() (string, error) { return pkg, nil })()
+			os.Clearenv()
+
+			// ACT
+			err := LoadWithOptions(tc.files, WithSearchParents(tc.stopAt...))
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.Map(t, GetVars()).Equals(tc.result)
+		})
+	}
+}
+
+func TestLoadWithOptions_WithSearchParents_WhenGetwdFails(t *testing.T) {
+	// ARRANGE
+	wderr := errors.New("getwd error")
+	defer State().Reset()
+	defer test.Using(&osGetwd, func() (string, error) { return "", wderr })()
+
+	// ACT
+	err := LoadWithOptions(nil, WithSearchParents())
+
+	// ASSERT
+	test.Error(t, err).Is(wderr)
+}
+
+func TestLoadWithOptions_WithSearchParents_WhenFileNotFound(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	defer State().Reset()
+	defer test.Using(&osGetwd, func() (string, error) { return dir, nil })()
+	defer test.Using(&osStat, func(name string) (fs.FileInfo, error) {
+		if name == filepath.Join(dir, ".git") {
+			return nil, nil
+		}
+		return nil, fs.ErrNotExist
+	})()
+
+	// ACT
+	err := LoadWithOptions(nil, WithSearchParents(".git"))
+
+	// ASSERT
+	test.Error(t, err).Is(fs.ErrNotExist)
+	test.That(t, err.Error()).Equals(".env: env.FileNotFoundError: searched: " + dir)
+}
@@ -0,0 +1,260 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// FileSuffix is the suffix added to the name of a variable to form the name of the
+// corresponding file variable when file indirection is enabled (see
+// WithFileIndirection).
+const FileSuffix = "_FILE"
+
+// Option is a function that configures the way in which variables are obtained by
+// Parse, Override and Bind.
+type Option func(*options)
+
+// options holds the configuration applied by Options.
+type options struct {
+	prefix       string
+	aliases      []string
+	deprecated   []string
+	onDeprecated func(used, preferred string)
+	files        bool
+	source       Source
+}
+
+// newOptions returns options configured by applying the specified Options.
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// lookup returns the value of a variable, applying the options.
+//
+// The variable is looked up using the name, followed by any aliases and then any
+// deprecated names, in the order specified, with the first that is set providing
+// the value.  If a deprecated name provides the value, the deprecation is reported.
+//
+// # returns
+//
+//	string   // the name of the variable that provided the value (including any
+//	         // prefix); if the variable is not set, the name (with any prefix)
+//
+//	string   // the value of the variable
+//
+//	bool     // true if the variable is set
+//
+//	error    // any error reading the value of a file variable (see
+//	         // WithFileIndirection); the name identifies the variable
+//	         // that could not be read
+func (o *options) lookup(name string) (string, string, bool, error) {
+	preferred := o.prefix + name
+	if name, v, ok, err := o.get(preferred); ok || err != nil {
+		return name, v, ok, err
+	}
+	for _, alias := range o.aliases {
+		if name, v, ok, err := o.get(o.prefix + alias); ok || err != nil {
+			return name, v, ok, err
+		}
+	}
+	for _, legacy := range o.deprecated {
+		if name, v, ok, err := o.get(o.prefix + legacy); ok || err != nil {
+			if ok {
+				o.deprecation(name, preferred)
+			}
+			return name, v, ok, err
+		}
+	}
+	return preferred, "", false, nil
+}
+
+// get returns the value of a variable with a given name or, if file indirection
+// is enabled and the variable is not set, the contents of the file identified by
+// the corresponding file variable.  The name returned is the name of the variable
+// that provided the value.
+func (o *options) get(name string) (string, string, bool, error) {
+	v, ok := o.lookupEnv(name)
+	if !o.files {
+		return name, v, ok, nil
+	}
+
+	fileVar := name + FileSuffix
+	path, isFile := o.lookupEnv(fileVar)
+	switch {
+	case !isFile:
+		return name, v, ok, nil
+	case ok:
+		return name, "", false, fmt.Errorf("%w: %s and %s are both set", ErrAmbiguousVariable, name, fileVar)
+	}
+
+	v, err := readSecret(path)
+	if err != nil {
+		return fileVar, "", false, err
+	}
+	return fileVar, v, true, nil
+}
+
+// lookupEnv returns the value of a variable from the source, if specified, or the
+// process environment.
+func (o *options) lookupEnv(name string) (string, bool) {
+	if o.source != nil {
+		return o.source.Lookup(name)
+	}
+	return osLookupEnv(name)
+}
+
+// readSecret returns the contents of a file, with any trailing newline removed.
+func readSecret(path string) (string, error) {
+	f, err := newFileReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	s := strings.TrimSuffix(string(b), "\n")
+	return strings.TrimSuffix(s, "\r"), nil
+}
+
+// deprecation reports the use of a deprecated name for a variable.
+func (o *options) deprecation(used, preferred string) {
+	if o.onDeprecated != nil {
+		o.onDeprecated(used, preferred)
+		return
+	}
+	slog.Warn("deprecated environment variable", "name", used, "preferred", preferred)
+}
+
+// Aliases returns an Option that specifies alternative names for a variable.  If
+// the variable is not set using the name, the aliases are tried in the order
+// specified; the first that is set provides the value:
+//
+//	proxy, err := env.Parse("HTTP_PROXY", as.AbsoluteURL, env.Aliases("http_proxy"))
+//
+// Any ParseError identifies the name that provided the value, or the name if no
+// alias is set.  Any prefix (see WithPrefix) is applied to aliases.
+//
+// Aliases is intended for use with Parse and Override; struct fields bound by
+// Bind specify aliases in the `env` tag.
+//
+// # parameters
+//
+//	names ...string   // the alternative names
+func Aliases(names ...string) Option {
+	return func(o *options) {
+		o.aliases = append(o.aliases, names...)
+	}
+}
+
+// Deprecated returns an Option that specifies legacy names for a variable.  Legacy
+// names are tried in the order specified, after the name and any aliases.  If a
+// legacy name provides the value the use of the deprecated name is reported (see
+// OnDeprecated):
+//
+//	dbURL, err := env.Parse("APP_DB_URL", as.String, env.Deprecated("DATABASE_URL"))
+//
+// Any ParseError identifies the name that provided the value.  Any prefix (see
+// WithPrefix) is applied to legacy names.
+//
+// Deprecated is intended for use with Parse and Override; struct fields bound by
+// Bind specify deprecated names in the `env` tag.
+//
+// # parameters
+//
+//	names ...string   // the legacy names
+func Deprecated(names ...string) Option {
+	return func(o *options) {
+		o.deprecated = append(o.deprecated, names...)
+	}
+}
+
+// From returns an Option that obtains variables from a Source instead of the
+// process environment:
+//
+//	vars, err := env.Read("config.env")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	port, err := env.Parse("PORT", as.PortNo, env.From(env.Chain(vars, env.OS())))
+//
+// Any file variables (see WithFileIndirection) are also obtained from the Source.
+//
+// # parameters
+//
+//	src Source   // the source of variables
+func From(src Source) Option {
+	return func(o *options) {
+		o.source = src
+	}
+}
+
+// OnDeprecated returns an Option that specifies a function to be called when a
+// deprecated name (see Deprecated) provides the value of a variable.  The function
+// is called with the deprecated name that was used and the preferred name (both
+// including any prefix).
+//
+// If no function is specified, a warning is logged using slog.Warn.
+//
+// # parameters
+//
+//	fn func(used, preferred string)   // the function to call
+func OnDeprecated(fn func(used, preferred string)) Option {
+	return func(o *options) {
+		o.onDeprecated = fn
+	}
+}
+
+// WithFileIndirection returns an Option that enables file indirection, following the
+// convention used for Docker secrets and Kubernetes mounted credentials.  If a
+// variable is not set, the corresponding file variable (the name of the variable
+// with FileSuffix added, e.g. DB_PASSWORD_FILE) is used to identify a file, with
+// the contents of the file (with any trailing newline removed) providing the value:
+//
+//	password, err := env.Parse("DB_PASSWORD", as.String, env.WithFileIndirection())
+//
+// If the file cannot be read, a ParseError identifying the file variable is
+// returned.  If both the variable and the file variable are set, a ParseError
+// wrapping ErrAmbiguousVariable is returned.
+//
+// When used with Bind, file indirection is enabled for all fields.  File indirection
+// may be enabled for individual fields using the `file` option in the `env` tag.
+func WithFileIndirection() Option {
+	return func(o *options) {
+		o.files = true
+	}
+}
+
+// WithPrefix returns an Option that adds a prefix to the names of variables.
+//
+// An Option returned by WithPrefix may be retained and used as a prefixed view of
+// the environment, scoping any number of Parse, Override or Bind calls to the
+// prefix:
+//
+//	replica := env.WithPrefix("REPLICA_")
+//
+//	host, err := env.Parse("DB_HOST", as.String, replica)       // REPLICA_DB_HOST
+//	_, err = env.Override(&port, "DB_PORT", as.PortNo, replica)  // REPLICA_DB_PORT
+//
+// When used with Bind, the prefix is applied to all fields, including those of
+// nested structs, in addition to any prefix specified for a nested struct.
+//
+// If more than one prefix is specified, the prefixes are combined in the order
+// specified.
+//
+// # parameters
+//
+//	prefix string   // the prefix to add
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix += prefix
+	}
+}
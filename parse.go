@@ -1,5 +1,7 @@
 package env
 
+import "errors"
+
 // ConversionFunc is a function that converts a string to a value of type T.
 // It is the type of the conversion function used by the Parse and Override
 // functions.
@@ -33,6 +35,8 @@ type ConversionFunc[T any] func(string) (T, error)
 //
 //	cnv func(string) (T, error)   // a function to parse the environment variable
 //
+//	opts ...Option                // (optional) options, e.g. WithPrefix
+//
 // # returns
 //
 //	bool    // true if the target variable was modified, false otherwise
@@ -49,8 +53,8 @@ type ConversionFunc[T any] func(string) (T, error)
 //     destination variable.
 //
 // Only the error result can determine if an error occurred.
-func Override[T comparable](dest *T, name string, cnv func(string) (T, error)) (bool, error) {
-	v, err := Parse(name, cnv)
+func Override[T comparable](dest *T, name string, cnv func(string) (T, error), opts ...Option) (bool, error) {
+	v, err := Parse(name, cnv, opts...)
 	switch {
 	case err == nil && *dest != v:
 		*dest = v
@@ -72,13 +76,15 @@ func Override[T comparable](dest *T, name string, cnv func(string) (T, error)) (
 //	                        // the function should return a value of type T and
 //	                        // an error if the value cannot be converted
 //
+//	opts ...Option          // (optional) options, e.g. WithPrefix
+//
 // # returns
 //
 //	T       // the value of the environment variable; if an error occurs the
 //	        // zero value of T is returned
 //
-//	error   // any error resulting from parsing the environment variable;
-//	        // if the error is ErrNotSet a nil error is returned
+//	error   // any error resulting from parsing the environment variable; if the
+//	        // variable is not set, a ParseError wrapping ErrNotSet
 //
 // # conversion functions
 //
@@ -86,13 +92,117 @@ func Override[T comparable](dest *T, name string, cnv func(string) (T, error)) (
 // this function.  For example, to parse an integer environment variable:
 //
 //	value, err := env.Parse("MY_INT_VAR", as.Int)
-func Parse[T any](name string, cnv ConversionFunc[T]) (T, error) {
-	if v, ok := osLookupEnv(name); ok {
+//
+// # options
+//
+// Options modify the way in which the variable is obtained.  For example, to parse
+// a variable with a prefix:
+//
+//	port, err := env.Parse("PORT", as.PortNo, env.WithPrefix("REPLICA_"))
+//
+// Any ParseError identifies the name of the variable including the prefix.
+//
+// # optional variables
+//
+// To distinguish a variable that is not set from one that is invalid without
+// testing for ErrNotSet, use ParseOr (to obtain a default value if the variable
+// is not set) or Optional.
+func Parse[T any](name string, cnv ConversionFunc[T], opts ...Option) (T, error) {
+	name, v, ok, err := newOptions(opts...).lookup(name)
+	if err != nil {
+		return *new(T), ParseError{VariableName: name, Err: err}
+	}
+	if ok {
 		r, err := cnv(v)
 		if err != nil {
-			return *new(T), ParseError{VariableName: name, Err: InvalidValueError{Value: v, Err: err}}
+			// a conversion error that is already an InvalidValueError is not wrapped
+			var ive InvalidValueError
+			if !errors.As(err, &ive) {
+				err = InvalidValueError{Value: v, Err: err}
+			}
+			return *new(T), ParseError{VariableName: name, Err: err}
 		}
 		return r, nil
 	}
 	return *new(T), ParseError{VariableName: name, Err: ErrNotSet}
 }
+
+// ParseOr parses the environment variable with the given name, as for Parse,
+// returning a default value if the variable is not set.
+//
+// The default is returned only if the variable is not set; if the variable is set
+// with a value that cannot be converted, the error is returned.
+//
+// # parameters
+//
+//	name string             // the name of the environment variable to parse
+//
+//	cnv ConversionFunc[T]   // a function to parse the environment variable
+//
+//	def T                   // the value to return if the variable is not set
+//
+//	opts ...Option          // (optional) options, e.g. WithPrefix
+//
+// # returns
+//
+//	T       // the value of the environment variable, or the default if the
+//	        // variable is not set; if an error occurs the zero value of T is
+//	        // returned
+//
+//	error   // any error resulting from parsing the environment variable
+func ParseOr[T any](name string, cnv ConversionFunc[T], def T, opts ...Option) (T, error) {
+	v, ok, err := Optional(name, cnv, opts...)
+	if err == nil && !ok {
+		return def, nil
+	}
+	return v, err
+}
+
+// MustParse parses the environment variable with the given name, as for Parse,
+// panicking if an error occurs.  The value of the panic is the ParseError.
+//
+// MustParse is intended for use when initialising package-level variables, or in
+// other circumstances where an application cannot proceed without the variable:
+//
+//	var dbHost = env.MustParse("DB_HOST", as.String)
+func MustParse[T any](name string, cnv ConversionFunc[T], opts ...Option) T {
+	v, err := Parse(name, cnv, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Optional parses the environment variable with the given name, as for Parse,
+// distinguishing a variable that is not set from one that is invalid.
+//
+// # returns
+//
+//	T       // the value of the environment variable; if the variable is not set
+//	        // or an error occurs, the zero value of T is returned
+//
+//	bool    // true if the variable is set, false otherwise
+//
+//	error   // any error resulting from converting the environment variable; a
+//	        // variable that is not set is not an error
+//
+// # example
+//
+//	port, ok, err := env.Optional("PORT", as.PortNo)
+//	switch {
+//	case err != nil:
+//		log.Fatal(err)
+//	case !ok:
+//		port = 8080
+//	}
+func Optional[T any](name string, cnv ConversionFunc[T], opts ...Option) (T, bool, error) {
+	v, err := Parse(name, cnv, opts...)
+	switch {
+	case errors.Is(err, ErrNotSet):
+		return v, false, nil
+	case err != nil:
+		return v, true, err
+	default:
+		return v, true, nil
+	}
+}
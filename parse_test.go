@@ -98,3 +98,254 @@ func TestOverride_WhenVariableIsNotSet(t *testing.T) {
 	test.IsFalse(t, result)
 	test.That(t, value).Equals(42)
 }
+
+func TestParse_WithPrefix(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("PORT", "8080")
+	os.Setenv("REPLICA_PORT", "8081")
+	replica := WithPrefix("REPLICA_")
+
+	// ACT
+	value, err := Parse("PORT", strconv.Atoi, replica)
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, value).Equals(8081)
+}
+
+func TestParse_WithPrefix_WhenVariableNotSet(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("PORT", "8080")
+
+	// ACT
+	_, err := Parse("PORT", strconv.Atoi, WithPrefix("REPLICA_"))
+
+	// ASSERT
+	test.Error(t, err).Is(ParseError{VariableName: "REPLICA_PORT", Err: ErrNotSet})
+}
+
+func TestOverride_WithPrefix(t *testing.T) {
+	// ARRANGE
+	var value = 42
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("VAR", "123")
+	os.Setenv("PREFIX_VAR", "456")
+
+	// ACT
+	result, err := Override(&value, "VAR", strconv.Atoi, WithPrefix("PREFIX_"))
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.IsTrue(t, result)
+	test.That(t, value).Equals(456)
+}
+
+func TestParseOr(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		env      map[string]string
+		result   int
+		err      error
+	}{
+		{scenario: "not set", result: 8080},
+		{scenario: "set", env: map[string]string{"PORT": "80"}, result: 80},
+		{scenario: "invalid", env: map[string]string{"PORT": "x"}, err: ParseError{VariableName: "PORT", Err: InvalidValueError{Value: "x"}}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			defer State().Reset()
+			os.Clearenv()
+			for k, v := range tc.env {
+				os.Setenv(k, v)
+			}
+
+			// ACT
+			result, err := ParseOr("PORT", strconv.Atoi, 8080)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("PORT", "80")
+
+	// ACT
+	result := MustParse("PORT", strconv.Atoi)
+
+	// ASSERT
+	test.That(t, result).Equals(80)
+}
+
+func TestMustParse_WhenVariableNotSet(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	defer test.ExpectPanic(ParseError{VariableName: "PORT", Err: ErrNotSet}).Assert(t)
+
+	// ACT
+	MustParse("PORT", strconv.Atoi)
+}
+
+func TestOptional(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		env      map[string]string
+		result   int
+		isSet    bool
+		err      error
+	}{
+		{scenario: "not set"},
+		{scenario: "set", env: map[string]string{"PORT": "80"}, result: 80, isSet: true},
+		{scenario: "invalid", env: map[string]string{"PORT": "x"}, isSet: true, err: ParseError{VariableName: "PORT", Err: InvalidValueError{Value: "x"}}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			defer State().Reset()
+			os.Clearenv()
+			for k, v := range tc.env {
+				os.Setenv(k, v)
+			}
+
+			// ACT
+			result, isSet, err := Optional("PORT", strconv.Atoi)
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+			test.Bool(t, isSet).Equals(tc.isSet)
+		})
+	}
+}
+
+func TestParse_WithAliases(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		env      map[string]string
+		result   string
+		name     string
+		used     string
+	}{
+		{scenario: "name", env: map[string]string{"APP_DB_URL": "app", "http_proxy": "alias", "DATABASE_URL": "legacy"}, result: "app", name: "APP_DB_URL"},
+		{scenario: "alias", env: map[string]string{"http_proxy": "alias", "DATABASE_URL": "legacy"}, result: "alias", name: "http_proxy"},
+		{scenario: "deprecated", env: map[string]string{"DATABASE_URL": "legacy"}, result: "legacy", name: "DATABASE_URL", used: "DATABASE_URL"},
+		{scenario: "not set", name: "APP_DB_URL"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			defer State().Reset()
+			os.Clearenv()
+			Vars(tc.env).Set()
+			used, preferred := "", ""
+			onDeprecated := func(u, p string) { used, preferred = u, p }
+
+			// ACT
+			result, err := Parse("APP_DB_URL", func(s string) (string, error) { return s, errors.New("fail") },
+				Aliases("http_proxy"),
+				Deprecated("DATABASE_URL"),
+				OnDeprecated(onDeprecated),
+			)
+
+			// ASSERT
+			test.Error(t, err).Is(ParseError{VariableName: tc.name})
+			test.That(t, result).Equals("")
+			if tc.result != "" {
+				test.Error(t, err).Is(InvalidValueError{Value: tc.result})
+			}
+			test.That(t, used).Equals(tc.used)
+			if tc.used != "" {
+				test.That(t, preferred).Equals("APP_DB_URL")
+			}
+		})
+	}
+}
+
+func TestOverride_WithAliasesAndPrefix(t *testing.T) {
+	// ARRANGE
+	var value = 42
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("PORT", "80")
+	os.Setenv("APP_PORT", "123")
+
+	// ACT
+	result, err := Override(&value, "SERVICE_PORT", strconv.Atoi, WithPrefix("APP_"), Aliases("PORT"))
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.IsTrue(t, result)
+	test.That(t, value).Equals(123)
+}
+
+func TestParse_WithFileIndirection(t *testing.T) {
+	// ARRANGE
+	readerr := errors.New("read error")
+	testcases := []struct {
+		scenario string
+		env      map[string]string
+		result   string
+		err      error
+	}{
+		{scenario: "variable set", env: map[string]string{"DB_PASSWORD": "value"}, result: "value"},
+		{scenario: "file variable set", env: map[string]string{"DB_PASSWORD_FILE": "/run/secrets/db_password"}, result: "secret"},
+		{scenario: "file variable set/crlf", env: map[string]string{"DB_PASSWORD_FILE": "crlf"}, result: "secret"},
+		{scenario: "file variable set/no newline", env: map[string]string{"DB_PASSWORD_FILE": "no-newline"}, result: "secret"},
+		{scenario: "file variable set/multiple newlines", env: map[string]string{"DB_PASSWORD_FILE": "newlines"}, result: "secret\n"},
+		{scenario: "file not readable", env: map[string]string{"DB_PASSWORD_FILE": "unreadable"}, err: ParseError{VariableName: "DB_PASSWORD_FILE", Err: readerr}},
+		{scenario: "both set", env: map[string]string{"DB_PASSWORD": "value", "DB_PASSWORD_FILE": "/run/secrets/db_password"}, err: ParseError{VariableName: "DB_PASSWORD", Err: ErrAmbiguousVariable}},
+		{scenario: "neither set", err: ParseError{VariableName: "DB_PASSWORD", Err: ErrNotSet}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			defer State().Reset()
+			os.Clearenv()
+			Vars(tc.env).Set()
+			defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+				switch path {
+				case "crlf":
+					return fakeFile("secret\r\n"), nil
+				case "no-newline":
+					return fakeFile("secret"), nil
+				case "newlines":
+					return fakeFile("secret\n\n"), nil
+				case "unreadable":
+					return nil, readerr
+				}
+				return fakeFile("secret\n"), nil
+			})()
+
+			// ACT
+			result, err := Parse("DB_PASSWORD", func(s string) (string, error) { return s, nil }, WithFileIndirection())
+
+			// ASSERT
+			test.Error(t, err).Is(tc.err)
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestParse_WithoutFileIndirection(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("DB_PASSWORD_FILE", "/run/secrets/db_password")
+
+	// ACT
+	_, err := Parse("DB_PASSWORD", func(s string) (string, error) { return s, nil })
+
+	// ASSERT
+	test.Error(t, err).Is(ErrNotSet)
+}
@@ -0,0 +1,220 @@
+package env
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// Parser collects the errors from any number of calls to ParseWith and OverrideWith,
+// so that all problems with the configuration of an application can be reported at
+// once rather than one at a time.
+//
+// # example
+//
+//	p := env.NewParser()
+//	port := 8080
+//	env.OverrideWith(p, &port, "PORT", as.PortNo)
+//	authURL := env.ParseWith(p, "AUTH_SERVICE_URL", as.AbsoluteURL)
+//	if err := p.Err(); err != nil {
+//		log.Fatal("invalid configuration:\n" + p.Report())
+//	}
+type Parser struct {
+	// Redact is a function that returns a redacted form of a value to be included
+	// in the Report.  If nil, the value is redacted by retaining only the first two
+	// characters, with the remainder replaced by asterisks.
+	Redact func(value string) string
+
+	opts []Option
+	errs []ParseError
+}
+
+// NewParser returns a new Parser.  Any options specified are applied to all calls
+// to ParseWith and OverrideWith using the Parser, in addition to any options
+// specified for each call.
+//
+// # parameters
+//
+//	opts ...Option   // (optional) options, e.g. WithPrefix
+//
+// # returns
+//
+//	*Parser   // a new Parser
+func NewParser(opts ...Option) *Parser {
+	return &Parser{opts: opts}
+}
+
+// ParseWith parses the environment variable with the given name, as for Parse,
+// recording any error with a Parser.  If the variable is not set, a ParseError
+// wrapping ErrNotSet is recorded.
+//
+// # parameters
+//
+//	p *Parser               // the parser to record any error
+//
+//	name string             // the name of the environment variable to parse
+//
+//	cnv ConversionFunc[T]   // a function to parse the environment variable
+//
+//	opts ...Option          // (optional) options, e.g. WithPrefix
+//
+// # returns
+//
+//	T   // the value of the environment variable; if an error occurs the zero
+//	    // value of T is returned
+func ParseWith[T any](p *Parser, name string, cnv ConversionFunc[T], opts ...Option) T {
+	v, err := Parse(name, cnv, append(p.opts[:len(p.opts):len(p.opts)], opts...)...)
+	p.record(err)
+	return v
+}
+
+// OverrideWith replaces the current value of some variable with the value obtained
+// by parsing a named environment variable, as for Override, recording any error
+// with a Parser.  A variable that is not set is not an error.
+//
+// # parameters
+//
+//	p *Parser                     // the parser to record any error
+//
+//	dest *T                       // a pointer to the variable to be changed
+//
+//	name string                   // the name of the environment variable to parse
+//
+//	cnv func(string) (T, error)   // a function to parse the environment variable
+//
+//	opts ...Option                // (optional) options, e.g. WithPrefix
+//
+// # returns
+//
+//	bool   // true if the target variable was modified, false otherwise
+func OverrideWith[T comparable](p *Parser, dest *T, name string, cnv func(string) (T, error), opts ...Option) bool {
+	ok, err := Override(dest, name, cnv, append(p.opts[:len(p.opts):len(p.opts)], opts...)...)
+	if !errors.Is(err, ErrNotSet) {
+		p.record(err)
+	}
+	return ok
+}
+
+// record records an error, if not nil.
+func (p *Parser) record(err error) {
+	var perr ParseError
+	switch {
+	case err == nil:
+		return
+	case errors.As(err, &perr):
+		p.errs = append(p.errs, perr)
+	default:
+		p.errs = append(p.errs, ParseError{Err: err})
+	}
+}
+
+// Err returns an error that joins the ParseErrors recorded by the Parser, in the
+// order recorded.  If no errors have been recorded the result is nil.
+func (p *Parser) Err() error {
+	errs := make([]error, len(p.errs))
+	for i, err := range p.errs {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
+
+// Errors returns the ParseErrors recorded by the Parser, in the order recorded.
+func (p *Parser) Errors() []ParseError {
+	return append([]ParseError(nil), p.errs...)
+}
+
+// Report returns a table of the problems recorded by the Parser, with a row for
+// each problem identifying the variable, the problem and the (redacted) value of
+// the variable:
+//
+//	VARIABLE          PROBLEM          VALUE
+//	PORT              invalid syntax   80********
+//	AUTH_SERVICE_URL  not set
+//
+// If no errors have been recorded the result is an empty string.
+func (p *Parser) Report() string {
+	if len(p.errs) == 0 {
+		return ""
+	}
+
+	redact := p.Redact
+	if redact == nil {
+		redact = redactValue
+	}
+
+	sb := &strings.Builder{}
+	tw := tabwriter.NewWriter(sb, 0, 0, 2, ' ', 0)
+	_, _ = tw.Write([]byte("VARIABLE\tPROBLEM\tVALUE\n"))
+	for _, err := range p.errs {
+		problem, value := "", ""
+		var ive InvalidValueError
+		if errors.As(err.Err, &ive) {
+			problem = describe(ive.Err)
+			value = redact(ive.Value)
+		} else {
+			problem = describe(err.Err)
+		}
+		_, _ = tw.Write([]byte(err.VariableName + "\t" + problem + "\t" + value + "\n"))
+	}
+	_ = tw.Flush()
+
+	return sb.String()
+}
+
+// elementError is implemented by errors identifying an element of a value that
+// could not be converted (such as as.ElementError).
+type elementError interface {
+	error
+	Element() string
+	Unwrap() error
+}
+
+// describe returns a description of an error for inclusion in a Report.  Errors
+// that may include the value of a variable (or part of it) in their description
+// (such as a strconv.NumError, url.Error or InvalidValueError) are described using
+// the underlying error; an error identifying an element of a value is described
+// by the element (index or key) and the description of the underlying error.
+func describe(err error) string {
+	var (
+		elem elementError
+		ive  InvalidValueError
+		nerr *strconv.NumError
+		uerr *url.Error
+		eerr url.EscapeError
+		herr url.InvalidHostError
+	)
+	switch {
+	case err == nil:
+		return "invalid value"
+	case errors.As(err, &elem):
+		return elem.Element() + ": " + describe(elem.Unwrap())
+	case errors.As(err, &ive):
+		return describe(ive.Err)
+	case errors.As(err, &uerr):
+		return describe(uerr.Err)
+	case errors.As(err, &nerr):
+		return nerr.Err.Error()
+	case errors.As(err, &eerr):
+		return "invalid URL escape"
+	case errors.As(err, &herr):
+		return "invalid character in host name"
+	default:
+		return err.Error()
+	}
+}
+
+// redactValue returns a redacted form of a value, retaining the first two
+// characters with the remainder (up to a maximum of 8 characters) replaced
+// by asterisks.
+func redactValue(value string) string {
+	n := utf8.RuneCountInString(value)
+	if n <= 2 {
+		return strings.Repeat("*", n)
+	}
+	_, w1 := utf8.DecodeRuneInString(value)
+	_, w2 := utf8.DecodeRuneInString(value[w1:])
+	return value[:w1+w2] + strings.Repeat("*", min(n-2, 8))
+}
@@ -0,0 +1,173 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/blugnu/test"
+)
+
+func TestParser(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("PORT", "8080")
+	os.Setenv("HOST", "example.com")
+
+	port := 80
+	p := NewParser()
+
+	// ACT
+	modified := OverrideWith(p, &port, "PORT", strconv.Atoi)
+	host := ParseWith(p, "HOST", func(s string) (string, error) { return s, nil })
+
+	// ASSERT
+	test.IsTrue(t, modified)
+	test.That(t, port).Equals(8080)
+	test.That(t, host).Equals("example.com")
+	test.Error(t, p.Err()).Is(nil)
+	test.That(t, p.Report()).Equals("")
+}
+
+func TestParser_WithErrors(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("PORT", "not-a-number")
+	os.Setenv("APP_TIMEOUT", "x")
+
+	port := 80
+	timeout := 30
+	p := NewParser()
+
+	// ACT
+	OverrideWith(p, &port, "PORT", strconv.Atoi)
+	OverrideWith(p, &timeout, "TIMEOUT", strconv.Atoi, WithPrefix("APP_"))
+	OverrideWith(p, &timeout, "NOT_SET", strconv.Atoi)
+	ParseWith(p, "AUTH_SERVICE_URL", func(s string) (string, error) { return s, nil })
+	err := p.Err()
+
+	// ASSERT
+	test.That(t, port).Equals(80)
+	test.That(t, timeout).Equals(30)
+	test.Error(t, err).Is(ParseError{VariableName: "PORT", Err: strconv.ErrSyntax})
+	test.Error(t, err).Is(ParseError{VariableName: "APP_TIMEOUT", Err: strconv.ErrSyntax})
+	test.Error(t, err).Is(ParseError{VariableName: "AUTH_SERVICE_URL", Err: ErrNotSet})
+	test.IsFalse(t, errors.Is(err, ParseError{VariableName: "NOT_SET"}))
+	test.That(t, len(p.Errors())).Equals(3)
+	test.That(t, p.Report()).Equals(
+		"VARIABLE          PROBLEM         VALUE\n" +
+			"PORT              invalid syntax  no********\n" +
+			"APP_TIMEOUT       invalid syntax  *\n" +
+			"AUTH_SERVICE_URL  not set         \n",
+	)
+}
+
+func TestParser_WithOptions(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("APP_PORT", "8080")
+
+	p := NewParser(WithPrefix("APP_"))
+
+	// ACT
+	port := ParseWith(p, "PORT", strconv.Atoi)
+
+	// ASSERT
+	test.That(t, port).Equals(8080)
+	test.Error(t, p.Err()).Is(nil)
+}
+
+func TestParser_Redact(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("PORT", "secret")
+
+	p := NewParser()
+	p.Redact = func(string) string { return "<redacted>" }
+
+	// ACT
+	ParseWith(p, "PORT", strconv.Atoi)
+
+	// ASSERT
+	test.That(t, p.Report()).Equals(
+		"VARIABLE  PROBLEM         VALUE\n" +
+			"PORT      invalid syntax  <redacted>\n",
+	)
+}
+
+// elementErr is an error identifying an element of a value, as for as.ElementError.
+type elementErr struct {
+	index int
+	err   error
+}
+
+func (e elementErr) Error() string   { return fmt.Sprintf("element %d: %v", e.index, e.err) }
+func (e elementErr) Element() string { return fmt.Sprintf("index %d", e.index) }
+func (e elementErr) Unwrap() error   { return e.err }
+
+func TestDescribe(t *testing.T) {
+	_, urlerr := url.Parse("ht tp://user:secret@host")
+	_, escerr := url.Parse("http://host/%zz")
+	_, hosterr := url.Parse("http://se cret/")
+	testcases := []struct {
+		scenario string
+		err      error
+		result   string
+	}{
+		{scenario: "nil", err: nil, result: "invalid value"},
+		{scenario: "error", err: ErrNotSet, result: "not set"},
+		{scenario: "strconv.NumError", err: &strconv.NumError{Func: "Atoi", Num: "secret", Err: strconv.ErrSyntax}, result: "invalid syntax"},
+		{scenario: "url.Error", err: urlerr, result: "first path segment in URL cannot contain colon"},
+		{scenario: "url.EscapeError", err: escerr, result: "invalid URL escape"},
+		{scenario: "url.InvalidHostError", err: hosterr, result: "invalid character in host name"},
+		{scenario: "InvalidValueError", err: InvalidValueError{Value: "secret", Err: errors.New("reason")}, result: "reason"},
+		{scenario: "wrapped InvalidValueError", err: fmt.Errorf("wrapped: %w", InvalidValueError{Value: "secret"}), result: "invalid value"},
+		{scenario: "element error",
+			err:    elementErr{index: 1, err: InvalidValueError{Value: "secret", Err: errors.New("reason")}},
+			result: "index 1: reason",
+		},
+		{scenario: "nested element errors",
+			err:    elementErr{index: 1, err: InvalidValueError{Value: "secret", Err: elementErr{index: 2, err: &strconv.NumError{Num: "secret", Err: strconv.ErrRange}}}},
+			result: "index 1: index 2: value out of range",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result := describe(tc.err)
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
+
+func TestRedactValue(t *testing.T) {
+	testcases := []struct {
+		scenario string
+		value    string
+		result   string
+	}{
+		{scenario: "empty", value: "", result: ""},
+		{scenario: "short", value: "ab", result: "**"},
+		{scenario: "longer", value: "abcde", result: "ab***"},
+		{scenario: "long", value: "abcdefghijklmnop", result: "ab********"},
+		{scenario: "multi-byte", value: "日本語です", result: "日本***"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ACT
+			result := redactValue(tc.value)
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+		})
+	}
+}
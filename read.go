@@ -0,0 +1,121 @@
+package env
+
+import (
+	"errors"
+	"io"
+)
+
+// Read reads variables from one or more files without applying them to the
+// environment.  Files are read according to the same rules as for Load, including
+// the rules for reading ".env".
+//
+// # parameters
+//
+//	files: ...string    // 0..n file path(s)
+//
+// # returns
+//
+//	Vars       // the variables read from the files; if a variable is read from more
+//	           // than one file, the value read from the last file is returned
+//
+//	error      // an error that wraps all errors that occurred while reading variables;
+//	           // if no errors occurred the result is nil
+//
+// References to other variables in values are expanded as for Load, resolving
+// references to variables read from earlier files in preference to variables in
+// the process environment.
+//
+// # example: comparing the variables in two files
+//
+//	dev, err := env.Read("dev.env")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	prod, err := env.Read("prod.env")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, name := range dev.Names() {
+//		if _, ok := prod[name]; !ok {
+//			log.Printf("%s is not set in prod.env", name)
+//		}
+//	}
+func Read(files ...string) (Vars, error) {
+	return newLoader().read(files)
+}
+
+// ReadDir reads variables from the files in a directory without applying them to
+// the environment.  Files are read according to the same rules as for LoadDir.
+//
+// # parameters
+//
+//	dir string           // the path of the directory
+//
+//	opts ...LoadOption   // options to apply (e.g. WithFS, WithNameTransform)
+//
+// # returns
+//
+//	Vars    // the variables read from the files in the directory
+//
+//	error   // an error that wraps all errors that occurred while reading variables;
+//	        // if no errors occurred the result is nil
+func ReadDir(dir string, opts ...LoadOption) (Vars, error) {
+	return newLoader(opts...).readDir(dir)
+}
+
+// ReadFrom reads variables from .env formatted content without applying them to
+// the environment.
+//
+// # parameters
+//
+//	r io.Reader   // the content to read
+//
+// # returns
+//
+//	Vars    // the variables read; if a variable is defined more than once the
+//	        // last value is returned
+//
+//	error   // an error that wraps all errors that occurred while reading variables;
+//	        // if no errors occurred the result is nil
+//
+// References to other variables in values are expanded as for Load.
+func ReadFrom(r io.Reader) (Vars, error) {
+	return readVars("", r, osLookupEnv, nil)
+}
+
+// readVars reads the variables from .env formatted content, expanding any references
+// in values.
+//
+// # parameters
+//
+//	path string                          // the path of the file from which the content is
+//	                                     // read, if any (identifies the file in any
+//	                                     // SyntaxError)
+//
+//	r io.Reader                          // the content to read
+//
+//	lookup func(string) (string, bool)   // a function returning variables that may be
+//	                                     // referenced by values in the content
+//
+//	retained func(string) bool           // (optional) a function identifying variables
+//	                                     // that are not replaced by the content (see
+//	                                     // expandEntries); may be nil
+//
+// # returns
+//
+//	Vars    // the variables read
+//
+//	error   // any errors that occurred while reading the content
+func readVars(path string, r io.Reader, lookup func(string) (string, bool), retained func(string) bool) (Vars, error) {
+	entries, err := readEntries(path, r)
+	errs := []error{err}
+
+	entries, err = expandEntries(entries, lookup, retained)
+	errs = append(errs, err)
+
+	vars := make(Vars, len(entries))
+	for _, e := range entries {
+		vars[e.name] = e.value
+	}
+	return vars, errors.Join(errs...)
+}
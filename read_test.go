@@ -0,0 +1,174 @@
+package env
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/blugnu/test"
+)
+
+func TestRead(t *testing.T) {
+	// ARRANGE
+	testcases := []struct {
+		scenario string
+		exec     func(t *testing.T)
+	}{
+		{scenario: "does not modify the environment",
+			exec: func(t *testing.T) {
+				// ARRANGE
+				defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+					return fakeFile("VAR1=loaded-value-1\nVAR2=loaded-value-2"), nil
+				})()
+				os.Setenv("VAR1", "env-value")
+
+				// ACT
+				result, err := Read()
+
+				// ASSERT
+				test.That(t, err).IsNil()
+				test.Map(t, result).Equals(Vars{"VAR1": "loaded-value-1", "VAR2": "loaded-value-2"})
+				test.Map(t, GetVars()).Equals(Vars{"VAR1": "env-value"})
+			},
+		},
+		{scenario: "later files replace earlier files",
+			exec: func(t *testing.T) {
+				// ARRANGE
+				filesRead := []string{}
+				defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+					filesRead = append(filesRead, path)
+					switch path {
+					case ".env":
+						return fakeFile("VAR1=dotenv-1\nVAR2=dotenv-2"), nil
+					case "test.env":
+						return fakeFile("VAR2=test-2"), nil
+					default:
+						panic("unexpected file path: " + path)
+					}
+				})()
+
+				// ACT
+				result, err := Read("test.env")
+
+				// ASSERT
+				test.That(t, err).IsNil()
+				test.Slice(t, filesRead).Equals([]string{".env", "test.env"})
+				test.Map(t, result).Equals(Vars{"VAR1": "dotenv-1", "VAR2": "test-2"})
+			},
+		},
+		{scenario: "references resolve earlier files before the environment",
+			exec: func(t *testing.T) {
+				// ARRANGE
+				defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+					switch path {
+					case ".env":
+						return fakeFile("HOST=dotenv.example.com"), nil
+					case "test.env":
+						return fakeFile("URL=http://${HOST}:${PORT}"), nil
+					default:
+						panic("unexpected file path: " + path)
+					}
+				})()
+				os.Setenv("HOST", "env.example.com")
+				os.Setenv("PORT", "8080")
+
+				// ACT
+				result, err := Read("test.env")
+
+				// ASSERT
+				test.That(t, err).IsNil()
+				test.That(t, result["URL"]).Equals("http://dotenv.example.com:8080")
+			},
+		},
+		{scenario: ".env does not exist",
+			exec: func(t *testing.T) {
+				// ARRANGE
+				defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+					switch path {
+					case ".env":
+						return nil, fs.ErrNotExist
+					case "test.env":
+						return fakeFile("VAR=test"), nil
+					default:
+						panic("unexpected file path: " + path)
+					}
+				})()
+
+				// ACT
+				result, err := Read("test.env")
+
+				// ASSERT
+				test.That(t, err).IsNil()
+				test.Map(t, result).Equals(Vars{"VAR": "test"})
+			},
+		},
+		{scenario: "errors",
+			exec: func(t *testing.T) {
+				// ARRANGE
+				readerr := errors.New("read error")
+				defer test.Using(&newFileReader, func(path string) (fileReader, error) {
+					switch path {
+					case ".env":
+						return nil, readerr
+					case "test.env":
+						return fakeFile("VAR1=value\nVAR2"), nil
+					default:
+						panic("unexpected file path: " + path)
+					}
+				})()
+
+				// ACT
+				result, err := Read("test.env")
+
+				// ASSERT
+				test.Error(t, err).Is(readerr)
+				test.Error(t, err).Is(SyntaxError{File: "test.env", Line: 2, Err: ErrMissingEquals})
+				test.Map(t, result).Equals(Vars{"VAR1": "value"})
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			defer State().Reset()
+			os.Clearenv()
+
+			tc.exec(t)
+		})
+	}
+}
+
+func TestReadFrom(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("HOST", "example.com")
+
+	// ACT
+	result, err := ReadFrom(strings.NewReader("URL=\"http://${HOST}\"\nVAR=value\n=invalid"))
+
+	// ASSERT
+	test.Error(t, err).Is(SyntaxError{Line: 3, Err: ErrMissingName})
+	test.Map(t, result).Equals(Vars{"URL": "http://example.com", "VAR": "value"})
+	test.Map(t, GetVars()).Equals(Vars{"HOST": "example.com"})
+}
+
+func TestReadDir(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	fsys := fstest.MapFS{
+		"config/db-host": {Data: []byte("db.example.com")},
+		"config/.hidden": {Data: []byte("hidden")},
+	}
+
+	// ACT
+	result, err := ReadDir("config", WithFS(fsys), WithNameTransform(UpperSnakeCase))
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.Map(t, result).Equals(Vars{"DB_HOST": "db.example.com"})
+	test.Map(t, GetVars()).Equals(Vars{})
+}
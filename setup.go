@@ -0,0 +1,77 @@
+package env
+
+import "testing"
+
+// Setup sets environment variables for the duration of a test.  Only the specified
+// variables are affected; when the test (and any subtests) complete, each variable
+// is restored to its previous value or, if it was not previously set, is unset.
+//
+// Since the process environment is shared by all tests, Setup cannot be used in
+// parallel tests, or tests with parallel ancestors; a test calling Setup after
+// calling t.Parallel fails (using t.Fatalf).  Parallel tests may use an isolated
+// Environment instead (see NewEnvironment).
+//
+// # parameters
+//
+//	t testing.TB   // the test
+//
+//	vars Vars      // the variables to set
+//
+// # example
+//
+//	func TestSomething(t *testing.T) {
+//		// ARRANGE
+//		env.Setup(t, env.Vars{
+//			"SOME_VAR":    "some value",
+//			"ANOTHER_VAR": "another value",
+//		})
+//
+//		// ACT
+//		SomeFuncUsingEnvVars()
+//
+//		// ASSERT
+//		...
+//	}
+func Setup(t testing.TB, vars Vars) {
+	t.Helper()
+	for _, name := range vars.Names() {
+		setenv(t, "env.Setup", name, vars[name])
+	}
+}
+
+// Unsetenv unsets environment variables for the duration of a test.  When the test
+// (and any subtests) complete, each variable that was previously set is restored
+// to its previous value.
+//
+// As for Setup, Unsetenv cannot be used in parallel tests.
+//
+// # parameters
+//
+//	t testing.TB       // the test
+//
+//	names ...string    // the names of the variables to unset
+func Unsetenv(t testing.TB, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		// t.Setenv records the current value (or that the variable is not set) to
+		// be restored when the test completes, and fails a parallel test
+		prev, _ := osLookupEnv(name)
+		setenv(t, "env.Unsetenv", name, prev)
+		if err := osUnsetenv(name); err != nil {
+			t.Fatalf("env.Unsetenv: %s: %v", name, err)
+		}
+	}
+}
+
+// setenv sets a variable using t.Setenv, failing the test if t.Setenv panics (as
+// it does in a parallel test) rather than allowing the panic to abort the test
+// binary.
+func setenv(t testing.TB, fn, name, value string) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("%s: %s: cannot set variables in a parallel test: %v", fn, name, r)
+		}
+	}()
+	t.Setenv(name, value)
+}
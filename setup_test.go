@@ -0,0 +1,102 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/blugnu/test"
+)
+
+func TestSetup(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("EXISTING", "original")
+	os.Setenv("UNCHANGED", "unchanged")
+
+	// ACT
+	t.Run("setup", func(t *testing.T) {
+		Setup(t, Vars{"EXISTING": "changed", "NEW": "new"})
+
+		// ASSERT
+		test.Map(t, GetVars()).Equals(Vars{"EXISTING": "changed", "NEW": "new", "UNCHANGED": "unchanged"})
+	})
+
+	// ASSERT
+	test.Map(t, GetVars()).Equals(Vars{"EXISTING": "original", "UNCHANGED": "unchanged"})
+}
+
+func TestUnsetenv(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("EXISTING", "original")
+	os.Setenv("EMPTY", "")
+	os.Setenv("UNCHANGED", "unchanged")
+
+	// ACT
+	t.Run("unsetenv", func(t *testing.T) {
+		Unsetenv(t, "EXISTING", "EMPTY", "NOT_SET")
+
+		// ASSERT
+		_, isSet := os.LookupEnv("NOT_SET")
+		test.IsFalse(t, isSet)
+		test.Map(t, GetVars()).Equals(Vars{"UNCHANGED": "unchanged"})
+	})
+
+	// ASSERT
+	_, isSet := os.LookupEnv("NOT_SET")
+	test.IsFalse(t, isSet)
+	test.Map(t, GetVars()).Equals(Vars{"EXISTING": "original", "EMPTY": "", "UNCHANGED": "unchanged"})
+}
+
+// fatalTB is a testing.TB that records a call to Fatalf, ending the goroutine
+// in which it is called (as for a testing.T).
+type fatalTB struct {
+	testing.TB
+	msg string
+}
+
+func (tb *fatalTB) Fatalf(format string, args ...any) {
+	tb.msg = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+func TestSetup_InParallelTest(t *testing.T) {
+	// ARRANGE
+	t.Parallel()
+	testcases := []struct {
+		scenario string
+		fn       func(testing.TB)
+		msg      string
+	}{
+		{scenario: "Setup",
+			fn:  func(tb testing.TB) { Setup(tb, Vars{"VAR": "value"}) },
+			msg: "env.Setup: VAR: cannot set variables in a parallel test",
+		},
+		{scenario: "Unsetenv",
+			fn:  func(tb testing.TB) { Unsetenv(tb, "VAR") },
+			msg: "env.Unsetenv: VAR: cannot set variables in a parallel test",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.scenario, func(t *testing.T) {
+			// ARRANGE
+			tb := &fatalTB{TB: t}
+			done := make(chan any)
+
+			// ACT
+			go func() {
+				defer func() { done <- recover() }()
+				tc.fn(tb)
+			}()
+			r := <-done
+
+			// ASSERT
+			test.That(t, r).IsNil()
+			test.String(t, tb.msg).Contains(tc.msg)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package env
+
+import (
+	"slices"
+)
+
+// Source is a source of variables.  A Source may be used to obtain variables with
+// Parse, Override and Bind (see From) instead of the process environment.
+//
+// The following are Sources:
+//
+//	OS()         // the process environment
+//
+//	Vars         // a map of variables, such as those returned by Read
+//	             // (from .env files) or ReadDir (from a directory)
+//
+//	Chain(...)   // a number of Sources, in order of precedence
+type Source interface {
+	// Lookup returns the value of a variable and true if the variable is set,
+	// otherwise an empty string and false.
+	Lookup(name string) (string, bool)
+
+	// Names returns the names of the variables that are set, sorted by name.
+	Names() []string
+}
+
+// OS returns a Source that obtains variables from the process environment.
+func OS() Source {
+	return osSource{}
+}
+
+// osSource is a Source that obtains variables from the process environment.
+type osSource struct{}
+
+// Lookup returns the value of an environment variable.
+func (osSource) Lookup(name string) (string, bool) {
+	return osLookupEnv(name)
+}
+
+// Names returns the names of all environment variables, sorted by name.
+func (osSource) Names() []string {
+	return GetVars().Names()
+}
+
+// Lookup returns the value of a variable in the map and true if the variable is
+// present, otherwise an empty string and false.  With Names, Lookup implements
+// the Source interface.
+func (v Vars) Lookup(name string) (string, bool) {
+	s, ok := v[name]
+	return s, ok
+}
+
+// Chain returns a Source that obtains variables from a number of Sources, in order
+// of precedence.  The value of a variable is obtained from the first Source in
+// which the variable is set.
+//
+// # example: variables in a file take precedence over the environment
+//
+//	vars, err := env.Read("config.env")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	src := env.Chain(vars, env.OS())
+//
+//	port, err := env.Parse("PORT", as.PortNo, env.From(src))
+func Chain(sources ...Source) Source {
+	return chain(sources)
+}
+
+// chain is a Source that obtains variables from a number of Sources.
+type chain []Source
+
+// Lookup returns the value of a variable from the first Source in which the
+// variable is set.
+func (c chain) Lookup(name string) (string, bool) {
+	for _, src := range c {
+		if v, ok := src.Lookup(name); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Names returns the names of the variables set in any of the Sources, sorted by
+// name.
+func (c chain) Names() []string {
+	names := []string{}
+	for _, src := range c {
+		names = append(names, src.Names()...)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
@@ -0,0 +1,126 @@
+package env
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/blugnu/test"
+)
+
+func TestOS(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("B", "b")
+	os.Setenv("A", "a")
+	src := OS()
+
+	// ACT
+	a, isSetA := src.Lookup("A")
+	_, isSetC := src.Lookup("C")
+	names := src.Names()
+
+	// ASSERT
+	test.That(t, a).Equals("a")
+	test.IsTrue(t, isSetA)
+	test.IsFalse(t, isSetC)
+	test.Slice(t, names).Equals([]string{"A", "B"})
+}
+
+func TestVars_Lookup(t *testing.T) {
+	// ARRANGE
+	vars := Vars{"A": "a", "EMPTY": ""}
+
+	// ACT
+	a, isSetA := vars.Lookup("A")
+	empty, isSetEmpty := vars.Lookup("EMPTY")
+	_, isSetC := vars.Lookup("C")
+
+	// ASSERT
+	test.That(t, a).Equals("a")
+	test.IsTrue(t, isSetA)
+	test.That(t, empty).Equals("")
+	test.IsTrue(t, isSetEmpty)
+	test.IsFalse(t, isSetC)
+}
+
+func TestChain(t *testing.T) {
+	// ARRANGE
+	src := Chain(
+		Vars{"A": "first", "B": "first"},
+		Vars{"B": "second", "C": "second"},
+	)
+	testcases := []struct {
+		name   string
+		result string
+		isSet  bool
+	}{
+		{name: "A", result: "first", isSet: true},
+		{name: "B", result: "first", isSet: true},
+		{name: "C", result: "second", isSet: true},
+		{name: "D"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			result, isSet := src.Lookup(tc.name)
+
+			// ASSERT
+			test.That(t, result).Equals(tc.result)
+			test.Bool(t, isSet).Equals(tc.isSet)
+		})
+	}
+
+	t.Run("names", func(t *testing.T) {
+		// ACT
+		names := src.Names()
+
+		// ASSERT
+		test.Slice(t, names).Equals([]string{"A", "B", "C"})
+	})
+}
+
+func TestParse_From(t *testing.T) {
+	// ARRANGE
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("PORT", "80")
+	os.Setenv("TIMEOUT", "30")
+	src := Chain(Vars{"PORT": "8080"}, OS())
+
+	// ACT
+	port, err1 := Parse("PORT", strconv.Atoi, From(src))
+	timeout, err2 := Parse("TIMEOUT", strconv.Atoi, From(src))
+	_, err3 := Parse("PORT", strconv.Atoi, From(Vars{}))
+
+	// ASSERT
+	test.That(t, err1).IsNil()
+	test.That(t, port).Equals(8080)
+	test.That(t, err2).IsNil()
+	test.That(t, timeout).Equals(30)
+	test.Error(t, err3).Is(ParseError{VariableName: "PORT", Err: ErrNotSet})
+}
+
+func TestBind_From(t *testing.T) {
+	// ARRANGE
+	type config struct {
+		Host     string `env:"HOST"`
+		Port     int    `env:"PORT"`
+		Password string `env:"PASSWORD,file"`
+	}
+	defer State().Reset()
+	os.Clearenv()
+	os.Setenv("HOST", "env.example.com")
+	defer test.Using(&newFileReader, func(string) (fileReader, error) {
+		return fakeFile("secret\n"), nil
+	})()
+	cfg := config{}
+
+	// ACT
+	err := Bind(&cfg, From(Vars{"PORT": "8080", "PASSWORD_FILE": "/run/secrets/password"}))
+
+	// ASSERT
+	test.That(t, err).IsNil()
+	test.That(t, cfg).Equals(config{Port: 8080, Password: "secret"})
+}